@@ -0,0 +1,219 @@
+// Command pacer-cli is an operator-facing companion to pacer-service: it
+// scrapes the service's /metrics endpoint and prints human-readable tables,
+// so on-call can check campaign activity from a terminal without Grafana.
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(1)
+	}
+
+	switch os.Args[1] {
+	case "metrics":
+		if err := runMetrics(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, "pacer-cli: "+err.Error())
+			os.Exit(1)
+		}
+	case "-h", "--help", "help":
+		usage()
+	default:
+		fmt.Fprintf(os.Stderr, "pacer-cli: unknown subcommand %q\n", os.Args[1])
+		usage()
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: pacer-cli metrics [--addr http://localhost:8080]")
+}
+
+// metricSample is one parsed Prometheus exposition line: a metric name,
+// its label set, and its value.
+type metricSample struct {
+	name   string
+	labels map[string]string
+	value  float64
+}
+
+func runMetrics(args []string) error {
+	addr := "http://localhost:8080"
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--addr" && i+1 < len(args) {
+			addr = args[i+1]
+			i++
+		}
+	}
+
+	samples, err := scrapeMetrics(addr + "/metrics")
+	if err != nil {
+		return fmt.Errorf("scraping %s/metrics: %w", addr, err)
+	}
+
+	printCampaignTable(samples)
+	return nil
+}
+
+func scrapeMetrics(url string) ([]metricSample, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var samples []metricSample
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		sample, ok := parseSample(line)
+		if !ok {
+			continue
+		}
+		samples = append(samples, sample)
+	}
+	return samples, scanner.Err()
+}
+
+// parseSample parses a single Prometheus text-exposition line of the form
+// `metric_name{label="value",...} 1.23`. It only needs to handle the
+// format client_golang emits, not the full exposition grammar.
+func parseSample(line string) (metricSample, bool) {
+	spaceIdx := strings.LastIndex(line, " ")
+	if spaceIdx < 0 {
+		return metricSample{}, false
+	}
+	value, err := strconv.ParseFloat(line[spaceIdx+1:], 64)
+	if err != nil {
+		return metricSample{}, false
+	}
+
+	head := line[:spaceIdx]
+	name := head
+	labels := make(map[string]string)
+
+	if braceIdx := strings.Index(head, "{"); braceIdx >= 0 && strings.HasSuffix(head, "}") {
+		name = head[:braceIdx]
+		body := head[braceIdx+1 : len(head)-1]
+		for _, pair := range splitLabelPairs(body) {
+			kv := strings.SplitN(pair, "=", 2)
+			if len(kv) != 2 {
+				continue
+			}
+			labels[kv[0]] = strings.Trim(kv[1], `"`)
+		}
+	}
+
+	return metricSample{name: name, labels: labels, value: value}, true
+}
+
+// splitLabelPairs splits a label body on commas that aren't inside a
+// quoted label value.
+func splitLabelPairs(body string) []string {
+	var pairs []string
+	var cur strings.Builder
+	inQuotes := false
+	for _, r := range body {
+		switch r {
+		case '"':
+			inQuotes = !inQuotes
+			cur.WriteRune(r)
+		case ',':
+			if inQuotes {
+				cur.WriteRune(r)
+			} else {
+				pairs = append(pairs, cur.String())
+				cur.Reset()
+			}
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if cur.Len() > 0 {
+		pairs = append(pairs, cur.String())
+	}
+	return pairs
+}
+
+// campaignRow aggregates the per-campaign metrics an operator cares about
+// most: bid volume, spend, and throttle rate.
+type campaignRow struct {
+	campaignID   string
+	decisions    float64
+	spendCents   float64
+	throttleRate float64
+	windowSize   float64
+}
+
+func printCampaignTable(samples []metricSample) {
+	rows := make(map[string]*campaignRow)
+	row := func(id string) *campaignRow {
+		if r, ok := rows[id]; ok {
+			return r
+		}
+		r := &campaignRow{campaignID: id}
+		rows[id] = r
+		return r
+	}
+
+	allowed := make(map[string]float64)
+	total := make(map[string]float64)
+
+	for _, s := range samples {
+		id := s.labels["campaign_id"]
+		if id == "" {
+			continue
+		}
+		switch s.name {
+		case "pacer_bid_decisions_total":
+			total[id] += s.value
+			if s.labels["reason"] == "within_budget" {
+				allowed[id] += s.value
+			}
+			row(id).decisions += s.value
+		case "pacer_spend_cents_total":
+			row(id).spendCents = s.value
+		case "pacer_throttle_rate":
+			row(id).throttleRate = s.value
+		case "pacer_throttle_window_size":
+			row(id).windowSize = s.value
+		}
+	}
+
+	ids := make([]string, 0, len(rows))
+	for id := range rows {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	w := os.Stdout
+	fmt.Fprintf(w, "%-24s %12s %10s %14s %10s\n", "CAMPAIGN", "DECISIONS", "ALLOWED%", "SPEND_CENTS", "WINDOW")
+	for _, id := range ids {
+		r := rows[id]
+		allowedPct := 0.0
+		if total[id] > 0 {
+			allowedPct = allowed[id] / total[id] * 100
+		}
+		fmt.Fprintf(w, "%-24s %12.0f %9.1f%% %14.0f %10.0f\n",
+			r.campaignID, r.decisions, allowedPct, r.spendCents, r.windowSize)
+	}
+	if len(ids) == 0 {
+		fmt.Fprintln(w, "(no campaign metrics found)")
+	}
+}
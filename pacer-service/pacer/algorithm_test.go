@@ -5,25 +5,24 @@ import (
 )
 
 func TestEvenPacingAlgorithm(t *testing.T) {
-	algo := &EvenPacingAlgorithm{}
-	
+	algo := &EvenPacing{}
+
 	status := &BudgetStatus{
-		DailyBudget:    10000,
-		DailySpent:     5000,
-		HourlyBudget:   1000,
-		HourlySpent:    500,
-		PacePercentage: 50.0,
+		DailyBudget:  24000,
+		DailySpent:   5000,
+		HourlyBudget: 1000,
+		HourlySpent:  500,
 	}
-	
+
 	throttle := algo.CalculateThrottle(status)
 	if throttle != 0.0 {
-		t.Errorf("Expected throttle 0.0 for even pacing at 50%%, got %f", throttle)
+		t.Errorf("Expected throttle 0.0 for even pacing when under the hourly target, got %f", throttle)
 	}
-	
+
 	if !algo.ShouldBid(status) {
-		t.Error("Expected bid to be allowed for even pacing at 50%")
+		t.Error("Expected bid to be allowed for even pacing when under the hourly target")
 	}
-	
+
 	maxBid := algo.GetMaxBid(5000, 10000)
 	if maxBid != 500 {
 		t.Errorf("Expected max bid 500, got %d", maxBid)
@@ -31,57 +30,57 @@ func TestEvenPacingAlgorithm(t *testing.T) {
 }
 
 func TestASAPPacingAlgorithm(t *testing.T) {
-	algo := &ASAPPacingAlgorithm{}
-	
+	algo := &ASAPPacing{}
+
 	status := &BudgetStatus{
-		DailyBudget:    10000,
-		DailySpent:     5000,
-		PacePercentage: 50.0,
+		DailyBudget: 10000,
+		DailySpent:  5000,
 	}
-	
+
 	throttle := algo.CalculateThrottle(status)
 	if throttle != 0.0 {
 		t.Errorf("Expected throttle 0.0 for ASAP pacing at 50%%, got %f", throttle)
 	}
-	
+
 	// Test high spend scenario
-	status.PacePercentage = 90.0
+	status.DailySpent = 9200
 	throttle = algo.CalculateThrottle(status)
 	if throttle <= 0.0 {
-		t.Error("Expected positive throttle for ASAP at 90% pace")
+		t.Error("Expected positive throttle for ASAP at 92% spend")
 	}
 }
 
 func TestFrontLoadedPacingAlgorithm(t *testing.T) {
-	algo := &FrontLoadedPacingAlgorithm{}
-	
+	algo := &FrontLoadedPacing{}
+
 	status := &BudgetStatus{
-		DailyBudget:    10000,
-		DailySpent:     2000,
-		PacePercentage: 20.0,
+		DailyBudget: 12000,
+		DailySpent:  2000,
+		HourlySpent: 500,
+		CurrentHour: 8,
 	}
-	
+
 	throttle := algo.CalculateThrottle(status)
 	if throttle != 0.0 {
-		t.Errorf("Expected no throttle for front-loaded at 20%%, got %f", throttle)
+		t.Errorf("Expected no throttle for front-loaded pacing under its first-half target, got %f", throttle)
 	}
-	
+
 	if !algo.ShouldBid(status) {
-		t.Error("Expected bid to be allowed for front-loaded pacing at 20%")
+		t.Error("Expected bid to be allowed for front-loaded pacing under its first-half target")
 	}
 }
 
 func TestAdaptivePacingAlgorithm(t *testing.T) {
-	algo := &AdaptivePacingAlgorithm{}
-	
+	algo := NewAdaptivePacing()
+
 	status := &BudgetStatus{
-		DailyBudget:    10000,
-		DailySpent:     5000,
-		HourlyBudget:   1000,
-		HourlySpent:    800,
-		PacePercentage: 50.0,
+		DailyBudget:  24000,
+		DailySpent:   5000,
+		HourlyBudget: 1500,
+		HourlySpent:  3000,
+		CurrentHour:  9, // historical multiplier 1.5
 	}
-	
+
 	// Test adaptation to high hourly spend
 	throttle := algo.CalculateThrottle(status)
 	if throttle <= 0.0 {
@@ -90,15 +89,15 @@ func TestAdaptivePacingAlgorithm(t *testing.T) {
 }
 
 func BenchmarkEvenPacingCalculation(b *testing.B) {
-	algo := &EvenPacingAlgorithm{}
+	algo := &EvenPacing{}
 	status := &BudgetStatus{
-		DailyBudget:    10000,
-		DailySpent:     5000,
-		PacePercentage: 50.0,
+		DailyBudget: 10000,
+		DailySpent:  5000,
+		HourlySpent: 500,
 	}
-	
+
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		algo.CalculateThrottle(status)
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,198 @@
+package pacer
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bits-and-blooms/bloom/v3"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	dedupHitsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "pacer_dedup_hits_total",
+			Help: "Count of duplicate bid requests short-circuited by BidDedup",
+		},
+		[]string{"campaign_id"},
+	)
+	dedupFalsePositiveEstimate = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "pacer_dedup_false_positive_estimate",
+			Help: "Configured false-positive rate of the active bloom filter generation",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(dedupHitsTotal, dedupFalsePositiveEstimate)
+}
+
+// CachedDecision is the minimal pacing outcome BidDedup replays for a
+// suppressed duplicate request, kept independent of main's HTTP response
+// type so this package doesn't import it.
+type CachedDecision struct {
+	AllowBid     bool
+	MaxBidCents  int64
+	ThrottleRate float64
+	Reason       string
+}
+
+// BidDedupConfig sizes BidDedup's rotating bloom filters from the
+// sustained request rate they need to cover over one dedup window.
+type BidDedupConfig struct {
+	ExpectedQPS   int           // sustained PacingDecisionRequest rate across all campaigns
+	Window        time.Duration // how long a bid_request_id is deduped for; defaults to 30s
+	FalsePositive float64       // bloom filter target false-positive rate; defaults to 0.01
+	StrictMode    bool          // use the exact LRU instead of the probabilistic bloom filter
+	CacheSize     int           // decision LRU capacity; defaults to 2x the estimated item count
+}
+
+// BidDedup short-circuits retried bidder requests that arrive within a
+// short window by recognizing (campaign_id, bid_request_id) pairs it has
+// already decided, returning the prior CachedDecision instead of
+// re-evaluating budget/circuit-breaker state. Two bloom filter
+// generations are kept and rotated every Window so entries naturally
+// expire after at most two windows. StrictMode callers skip the bloom
+// filter and rely solely on the bounded decision LRU, trading memory for
+// an exact (no false-positive) dedup guarantee.
+type BidDedup struct {
+	mu       sync.Mutex
+	current  *bloom.BloomFilter
+	previous *bloom.BloomFilter
+	n        uint
+	fpr      float64
+
+	window     time.Duration
+	strictMode bool
+	decisions  *lruDecisionCache
+
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+func NewBidDedup(cfg BidDedupConfig) *BidDedup {
+	if cfg.Window <= 0 {
+		cfg.Window = 30 * time.Second
+	}
+	if cfg.FalsePositive <= 0 {
+		cfg.FalsePositive = 0.01
+	}
+
+	n := uint(cfg.ExpectedQPS) * uint(cfg.Window/time.Second)
+	if n == 0 {
+		n = 10000
+	}
+
+	cacheSize := cfg.CacheSize
+	if cacheSize <= 0 {
+		cacheSize = int(n) * 2
+	}
+
+	d := &BidDedup{
+		current:    bloom.NewWithEstimates(n, cfg.FalsePositive),
+		previous:   bloom.NewWithEstimates(n, cfg.FalsePositive),
+		n:          n,
+		fpr:        cfg.FalsePositive,
+		window:     cfg.Window,
+		strictMode: cfg.StrictMode,
+		decisions:  newLRUDecisionCache(cacheSize),
+		stopCh:     make(chan struct{}),
+		doneCh:     make(chan struct{}),
+	}
+
+	dedupFalsePositiveEstimate.Set(cfg.FalsePositive)
+	go d.rotateLoop()
+	return d
+}
+
+// rotateLoop ages out the older bloom filter generation every Window,
+// so a bid_request_id is only ever deduped within roughly one to two
+// windows of its first sighting.
+func (d *BidDedup) rotateLoop() {
+	defer close(d.doneCh)
+
+	ticker := time.NewTicker(d.window)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			d.mu.Lock()
+			d.previous = d.current
+			d.current = bloom.NewWithEstimates(d.n, d.fpr)
+			d.mu.Unlock()
+		case <-d.stopCh:
+			return
+		}
+	}
+}
+
+// Stop ends the background rotation loop. Safe to call once.
+func (d *BidDedup) Stop() {
+	close(d.stopCh)
+	<-d.doneCh
+}
+
+func dedupKey(campaignID, bidRequestID string) string {
+	return fmt.Sprintf("%s:%s", campaignID, bidRequestID)
+}
+
+// Check reports whether (campaignID, bidRequestID) has already been
+// decided within the current dedup window. In strict mode the window does
+// not apply: a recorded pair counts as a duplicate until the LRU evicts it.
+// ok is false for an empty bidRequestID, since dedup only applies to
+// callers that opt in with one.
+func (d *BidDedup) Check(campaignID, bidRequestID string) (CachedDecision, bool) {
+	if bidRequestID == "" {
+		return CachedDecision{}, false
+	}
+	key := dedupKey(campaignID, bidRequestID)
+
+	if d.strictMode {
+		decision, ok := d.decisions.get(key)
+		if ok {
+			dedupHitsTotal.WithLabelValues(campaignID).Inc()
+		}
+		return decision, ok
+	}
+
+	keyBytes := []byte(key)
+	d.mu.Lock()
+	seen := d.current.Test(keyBytes) || d.previous.Test(keyBytes)
+	d.mu.Unlock()
+	if !seen {
+		return CachedDecision{}, false
+	}
+
+	decision, ok := d.decisions.get(key)
+	if !ok {
+		// The bloom filter claims membership but we have no cached
+		// decision for it -- a false positive, or it aged out of the
+		// LRU before its bloom entry rotated out. Treat it as not a
+		// duplicate rather than blocking a legitimate bid.
+		return CachedDecision{}, false
+	}
+
+	dedupHitsTotal.WithLabelValues(campaignID).Inc()
+	return decision, true
+}
+
+// Record stores decision as the canonical outcome for (campaignID,
+// bidRequestID), so a retried request with the same ID short-circuits to
+// it on its next Check instead of re-evaluating budget/circuit-breaker
+// state.
+func (d *BidDedup) Record(campaignID, bidRequestID string, decision CachedDecision) {
+	if bidRequestID == "" {
+		return
+	}
+	key := dedupKey(campaignID, bidRequestID)
+
+	if !d.strictMode {
+		d.mu.Lock()
+		d.current.Add([]byte(key))
+		d.mu.Unlock()
+	}
+	d.decisions.put(key, decision)
+}
@@ -0,0 +1,61 @@
+package pacer
+
+import "testing"
+
+func TestBidDedup_SuppressesRetryWithSameBidRequestID(t *testing.T) {
+	d := NewBidDedup(BidDedupConfig{ExpectedQPS: 10, Window: 0})
+	defer d.Stop()
+
+	if _, dup := d.Check("camp-1", "req-1"); dup {
+		t.Fatalf("expected first sighting of req-1 to not be a duplicate")
+	}
+
+	want := CachedDecision{AllowBid: true, MaxBidCents: 100, ThrottleRate: 0.2, Reason: "within_budget"}
+	d.Record("camp-1", "req-1", want)
+
+	got, dup := d.Check("camp-1", "req-1")
+	if !dup {
+		t.Fatalf("expected retried req-1 to be recognized as a duplicate")
+	}
+	if got != want {
+		t.Fatalf("expected replayed decision %+v, got %+v", want, got)
+	}
+}
+
+func TestBidDedup_DistinctCampaignsDoNotCollide(t *testing.T) {
+	d := NewBidDedup(BidDedupConfig{ExpectedQPS: 10})
+	defer d.Stop()
+
+	d.Record("camp-1", "req-1", CachedDecision{AllowBid: true, Reason: "within_budget"})
+
+	if _, dup := d.Check("camp-2", "req-1"); dup {
+		t.Fatalf("same bid_request_id under a different campaign must not dedup")
+	}
+}
+
+func TestBidDedup_EmptyBidRequestIDNeverDedups(t *testing.T) {
+	d := NewBidDedup(BidDedupConfig{ExpectedQPS: 10})
+	defer d.Stop()
+
+	d.Record("camp-1", "", CachedDecision{AllowBid: true})
+	if _, dup := d.Check("camp-1", ""); dup {
+		t.Fatalf("an empty bid_request_id should never be treated as a duplicate")
+	}
+}
+
+func TestBidDedup_StrictModeIsExact(t *testing.T) {
+	d := NewBidDedup(BidDedupConfig{ExpectedQPS: 10, StrictMode: true})
+	defer d.Stop()
+
+	want := CachedDecision{AllowBid: false, Reason: "budget_exhausted"}
+	d.Record("camp-1", "req-9", want)
+
+	got, dup := d.Check("camp-1", "req-9")
+	if !dup || got != want {
+		t.Fatalf("expected strict-mode exact dedup hit, got dup=%v decision=%+v", dup, got)
+	}
+
+	if _, dup := d.Check("camp-1", "req-unknown"); dup {
+		t.Fatalf("strict mode must not report a duplicate for an unrecorded id")
+	}
+}
@@ -0,0 +1,160 @@
+package pacer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	pacerproto "github.com/ad-budget-pacer/pacer-service/pacer/proto"
+)
+
+// recordField is the single HASH field a BudgetRecord is stored under, per
+// proto/budget.proto's "record" field described in BudgetRecord's doc
+// comment -- left a dedicated field (rather than the HASH's only field)
+// so sibling fields can be added later without touching this encoding.
+const recordField = "record"
+
+// recordTTL is how long a budget:v2:* HASH survives without a write,
+// matching the old dayKey's 25-hour TTL (24h of decisions plus slack for
+// clock skew across instances).
+const recordTTL = 25 * time.Hour
+
+// getRecordKey returns the HASH key a campaign-day's BudgetRecord is
+// stored under, replacing the separate getDayKey/getHourKey string
+// counters with one structured record per campaign-day.
+func (bt *ResilientBudgetTracker) getRecordKey(campaignID string, t time.Time) string {
+	return fmt.Sprintf("budget:v2:%s:%s", campaignID, t.Format("2006-01-02"))
+}
+
+// getLegacyDayKey and getLegacyHourKey reproduce the pre-migration
+// stringified-int key formats so loadRecord can fall back to them for a
+// campaign-day that hasn't been written under the v2 encoding yet.
+func (bt *ResilientBudgetTracker) getLegacyDayKey(campaignID string, t time.Time) string {
+	return fmt.Sprintf("budget:day:%s:%s", campaignID, t.Format("2006-01-02"))
+}
+
+func (bt *ResilientBudgetTracker) getLegacyHourKey(campaignID string, date string, hour int) string {
+	return fmt.Sprintf("budget:hour:%s:%s-%02d", campaignID, date, hour)
+}
+
+// loadRecord fetches and decodes campaignID's BudgetRecord for t's date,
+// returning a freshly-initialized record (not an error) if none exists yet.
+// If no v2 record exists, it falls back to reading the pre-migration
+// stringified-int keys (getLegacyDayKey/getLegacyHourKey) so in-flight
+// spend survives a live cutover instead of silently resetting to zero;
+// a legacy hit is written back as a v2 record so later reads skip this
+// fallback. Once every campaign-day has been touched since the cutover,
+// the legacy keys are unused and expire on their own TTL.
+func (bt *ResilientBudgetTracker) loadRecord(ctx context.Context, campaignID string, t time.Time) (*pacerproto.BudgetRecord, error) {
+	fields, err := bt.store.HGetAll(ctx, bt.getRecordKey(campaignID, t))
+	if err != nil {
+		return nil, err
+	}
+
+	rec := &pacerproto.BudgetRecord{CampaignId: campaignID, Date: t.Format("2006-01-02")}
+	if raw, ok := fields[recordField]; ok && len(raw) > 0 {
+		if err := rec.Unmarshal(raw); err != nil {
+			return nil, err
+		}
+		return rec, nil
+	}
+
+	legacyRec, migrated, err := bt.loadLegacyRecord(ctx, campaignID, t)
+	if err != nil {
+		return nil, err
+	}
+	if !migrated {
+		return rec, nil
+	}
+	if err := bt.saveRecord(ctx, campaignID, t, legacyRec); err != nil {
+		log.WithError(err).WithField("campaign_id", campaignID).Warn("failed to persist record migrated from legacy budget keys")
+	}
+	return legacyRec, nil
+}
+
+// loadLegacyRecord reads campaignID's pre-migration budget:day/budget:hour
+// stringified-int counters for t's date and assembles them into a
+// BudgetRecord. migrated is false (with a zero-value record) when none of
+// the legacy keys exist, so callers can tell a genuinely new campaign-day
+// apart from one worth migrating.
+func (bt *ResilientBudgetTracker) loadLegacyRecord(ctx context.Context, campaignID string, t time.Time) (rec *pacerproto.BudgetRecord, migrated bool, err error) {
+	date := t.Format("2006-01-02")
+	rec = &pacerproto.BudgetRecord{CampaignId: campaignID, Date: date}
+
+	pipe := bt.store.Pipeline()
+	dayCmd := pipe.Get(ctx, bt.getLegacyDayKey(campaignID, t))
+	hourCmds := make([]BudgetStringCmd, 24)
+	for hour := 0; hour < 24; hour++ {
+		hourCmds[hour] = pipe.Get(ctx, bt.getLegacyHourKey(campaignID, date, hour))
+	}
+	if err := pipe.Exec(ctx); err != nil && !errors.Is(err, ErrBudgetKeyNotFound) {
+		return nil, false, err
+	}
+
+	if dayCmd.Val() != "" {
+		if v, perr := strconv.ParseInt(dayCmd.Val(), 10, 64); perr == nil {
+			rec.DailySpentCents = v
+			migrated = true
+		}
+	}
+	for hour, cmd := range hourCmds {
+		if cmd.Val() == "" {
+			continue
+		}
+		if v, perr := strconv.ParseInt(cmd.Val(), 10, 64); perr == nil {
+			setHourlySpent(rec, hour, v)
+			migrated = true
+		}
+	}
+	return rec, migrated, nil
+}
+
+// saveRecord marshals rec and writes it back to t's HASH key, refreshing
+// recordTTL. t must be the same date rec was loaded for.
+func (bt *ResilientBudgetTracker) saveRecord(ctx context.Context, campaignID string, t time.Time, rec *pacerproto.BudgetRecord) error {
+	data, err := rec.Marshal()
+	if err != nil {
+		return err
+	}
+	return bt.store.HSet(ctx, bt.getRecordKey(campaignID, t), map[string][]byte{recordField: data}, recordTTL)
+}
+
+// applyHourlyDelta adds amount to rec's bucket for hour, appending a new
+// bucket if this is the first spend recorded for that hour this campaign-day.
+func applyHourlyDelta(rec *pacerproto.BudgetRecord, hour int, amount int64) {
+	for i := range rec.Hourly {
+		if int(rec.Hourly[i].Hour) == hour {
+			rec.Hourly[i].SpentCents += amount
+			return
+		}
+	}
+	rec.Hourly = append(rec.Hourly, pacerproto.HourlyBucket{Hour: int32(hour), SpentCents: amount})
+}
+
+// hourlySpent returns rec's accumulated spend for hour, or 0 if nothing has
+// posted to that hour yet.
+func hourlySpent(rec *pacerproto.BudgetRecord, hour int) int64 {
+	for _, b := range rec.Hourly {
+		if int(b.Hour) == hour {
+			return b.SpentCents
+		}
+	}
+	return 0
+}
+
+// setHourlySpent overwrites rec's bucket for hour with an absolute value,
+// used by recovery sync which restores memory-cache snapshots rather than
+// deltas.
+func setHourlySpent(rec *pacerproto.BudgetRecord, hour int, spent int64) {
+	for i := range rec.Hourly {
+		if int(rec.Hourly[i].Hour) == hour {
+			rec.Hourly[i].SpentCents = spent
+			return
+		}
+	}
+	rec.Hourly = append(rec.Hourly, pacerproto.HourlyBucket{Hour: int32(hour), SpentCents: spent})
+}
@@ -0,0 +1,155 @@
+package pacer
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func newTestResilientTracker() *ResilientBudgetTracker {
+	return &ResilientBudgetTracker{
+		store:         NewMemoryBudgetStore(),
+		memoryCache:   make(map[string]*MemoryBudget),
+		recoveryQueue: make(map[string]*MemoryBudget),
+		// storeBreaker's zero value is already a usable, closed breaker.
+	}
+}
+
+func TestLoadRecord_ReturnsFreshRecordWhenKeyMissing(t *testing.T) {
+	bt := newTestResilientTracker()
+	now := time.Now()
+
+	rec, err := bt.loadRecord(context.Background(), "camp-1", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rec.CampaignId != "camp-1" || rec.Date != now.Format("2006-01-02") {
+		t.Fatalf("expected a freshly-initialized record, got %+v", rec)
+	}
+	if rec.DailySpentCents != 0 || len(rec.Hourly) != 0 {
+		t.Fatalf("expected a zero-value record, got %+v", rec)
+	}
+}
+
+func TestSaveRecordThenLoadRecord_RoundTrips(t *testing.T) {
+	bt := newTestResilientTracker()
+	now := time.Now()
+
+	rec, err := bt.loadRecord(context.Background(), "camp-1", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rec.DailySpentCents = 5000
+	applyHourlyDelta(rec, now.Hour(), 5000)
+	rec.Version = 1
+
+	if err := bt.saveRecord(context.Background(), "camp-1", now, rec); err != nil {
+		t.Fatalf("unexpected save error: %v", err)
+	}
+
+	got, err := bt.loadRecord(context.Background(), "camp-1", now)
+	if err != nil {
+		t.Fatalf("unexpected load error: %v", err)
+	}
+	if got.DailySpentCents != 5000 || got.Version != 1 {
+		t.Fatalf("expected round-tripped record, got %+v", got)
+	}
+	if hourlySpent(got, now.Hour()) != 5000 {
+		t.Fatalf("expected hourly bucket to round trip, got %+v", got.Hourly)
+	}
+}
+
+func TestApplyHourlyDelta_AccumulatesSameHour(t *testing.T) {
+	rec, err := newTestResilientTracker().loadRecord(context.Background(), "camp-1", time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	applyHourlyDelta(rec, 9, 100)
+	applyHourlyDelta(rec, 9, 50)
+	applyHourlyDelta(rec, 10, 25)
+
+	if hourlySpent(rec, 9) != 150 {
+		t.Fatalf("expected hour 9 to accumulate to 150, got %d", hourlySpent(rec, 9))
+	}
+	if hourlySpent(rec, 10) != 25 {
+		t.Fatalf("expected hour 10 to hold 25, got %d", hourlySpent(rec, 10))
+	}
+	if len(rec.Hourly) != 2 {
+		t.Fatalf("expected exactly two hourly buckets, got %d", len(rec.Hourly))
+	}
+}
+
+func TestSetHourlySpent_OverwritesOnlyTargetHour(t *testing.T) {
+	rec, err := newTestResilientTracker().loadRecord(context.Background(), "camp-1", time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	applyHourlyDelta(rec, 9, 100)
+
+	setHourlySpent(rec, 9, 999)
+	setHourlySpent(rec, 14, 42)
+
+	if hourlySpent(rec, 9) != 999 {
+		t.Fatalf("expected hour 9 to be overwritten to 999, got %d", hourlySpent(rec, 9))
+	}
+	if hourlySpent(rec, 14) != 42 {
+		t.Fatalf("expected new hour 14 bucket of 42, got %d", hourlySpent(rec, 14))
+	}
+}
+
+func TestLoadRecord_MigratesLegacyStringifiedIntKeys(t *testing.T) {
+	bt := newTestResilientTracker()
+	ctx := context.Background()
+	now := time.Now()
+	date := now.Format("2006-01-02")
+
+	pipe := bt.store.Pipeline()
+	pipe.Set(ctx, bt.getLegacyDayKey("camp-1", now), 7500, recordTTL)
+	pipe.Set(ctx, bt.getLegacyHourKey("camp-1", date, now.Hour()), 2500, recordTTL)
+	if err := pipe.Exec(ctx); err != nil {
+		t.Fatalf("unexpected error seeding legacy keys: %v", err)
+	}
+
+	rec, err := bt.loadRecord(ctx, "camp-1", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rec.DailySpentCents != 7500 {
+		t.Fatalf("expected DailySpentCents migrated from the legacy day key, got %d", rec.DailySpentCents)
+	}
+	if hourlySpent(rec, now.Hour()) != 2500 {
+		t.Fatalf("expected hourly bucket migrated from the legacy hour key, got %+v", rec.Hourly)
+	}
+
+	// The migrated record should now be readable straight from the v2
+	// HASH, without touching the legacy keys again.
+	fields, err := bt.store.HGetAll(ctx, bt.getRecordKey("camp-1", now))
+	if err != nil {
+		t.Fatalf("unexpected error reading v2 record: %v", err)
+	}
+	if _, ok := fields[recordField]; !ok {
+		t.Fatalf("expected the legacy record to have been persisted under the v2 key")
+	}
+}
+
+func TestResilientBudgetTracker_TrackSpendThenGetBudgetStatus_UsesRecordScheme(t *testing.T) {
+	bt := NewResilientBudgetTracker(NewMemoryBudgetStore())
+	defer bt.Close()
+
+	ctx := context.Background()
+	if err := bt.TrackSpend(ctx, "camp-1", 1000); err != nil {
+		t.Fatalf("unexpected TrackSpend error: %v", err)
+	}
+	// TrackSpend writes to the store asynchronously; give the goroutine a
+	// moment to land before reading it back.
+	time.Sleep(50 * time.Millisecond)
+
+	status, err := bt.getFromStore(ctx, "camp-1", 24000, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected getFromStore error: %v", err)
+	}
+	if status.DailySpent != 1000 {
+		t.Fatalf("expected DailySpent 1000 from the v2 record, got %d", status.DailySpent)
+	}
+}
@@ -0,0 +1,356 @@
+package pacer
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// ErrBudgetKeyNotFound is returned by a BudgetPipeline's Exec when one of
+// its queued Get operations targeted a key that doesn't exist -- the
+// BudgetStore equivalent of redis.Nil, kept independent of go-redis so
+// ResilientBudgetTracker doesn't need to import it directly.
+var ErrBudgetKeyNotFound = errors.New("budgetstore: key not found")
+
+// BudgetStringCmd is the result of a pipelined Get, populated once its
+// owning BudgetPipeline's Exec has run, mirroring *redis.StringCmd's
+// Val()/Err() so a *redis.StringCmd satisfies this interface unmodified.
+type BudgetStringCmd interface {
+	Val() string
+	Err() error
+}
+
+// BudgetPipeline batches IncrBy/Expire/Get/Set operations to be executed
+// together by Exec, mirroring redis.Pipeliner's calling convention.
+type BudgetPipeline interface {
+	IncrBy(ctx context.Context, key string, value int64)
+	Expire(ctx context.Context, key string, ttl time.Duration)
+	Get(ctx context.Context, key string) BudgetStringCmd
+	Set(ctx context.Context, key string, value int64, ttl time.Duration)
+	Exec(ctx context.Context) error
+}
+
+// BudgetStore abstracts the counter storage ResilientBudgetTracker depends
+// on, so the tracker no longer hard-codes *redis.Client and can run against
+// standalone Redis, Redis Sentinel, or Redis Cluster (all via
+// redis.UniversalClient), an in-process map (tests, single-node deploys),
+// or a null store (intentionally memory-only operation).
+type BudgetStore interface {
+	Pipeline() BudgetPipeline
+	Ping(ctx context.Context) error
+
+	// HGetAll returns every field of the HASH at key, or an empty map if
+	// key doesn't exist -- used by the v2 BudgetRecord storage scheme.
+	HGetAll(ctx context.Context, key string) (map[string][]byte, error)
+	// HSet replaces fields of the HASH at key and applies ttl if > 0.
+	HSet(ctx context.Context, key string, fields map[string][]byte, ttl time.Duration) error
+}
+
+// redisBudgetStore is the production BudgetStore, backed by
+// redis.UniversalClient so the same tracker code runs unmodified whether
+// opts describes a standalone node, a Sentinel setup, or a Cluster.
+type redisBudgetStore struct {
+	client redis.UniversalClient
+}
+
+// NewRedisBudgetStore builds a BudgetStore from Universal options, the same
+// Addrs/MasterName/RouteRandomly-style configuration go-redis uses to
+// select standalone, Sentinel, or Cluster mode at dial time. Pool/timeout
+// fields left at their zero value get the same production defaults the
+// tracker used to hardcode, so callers only need to set Addrs (and
+// MasterName/cluster-specific fields, where applicable).
+func NewRedisBudgetStore(opts *redis.UniversalOptions) BudgetStore {
+	if opts.PoolSize == 0 {
+		opts.PoolSize = 100
+	}
+	if opts.MinIdleConns == 0 {
+		opts.MinIdleConns = 10
+	}
+	if opts.MaxRetries == 0 {
+		opts.MaxRetries = 2
+	}
+	if opts.DialTimeout == 0 {
+		opts.DialTimeout = 1 * time.Second
+	}
+	if opts.ReadTimeout == 0 {
+		opts.ReadTimeout = 1 * time.Second
+	}
+	if opts.WriteTimeout == 0 {
+		opts.WriteTimeout = 1 * time.Second
+	}
+	return &redisBudgetStore{client: redis.NewUniversalClient(opts)}
+}
+
+func (s *redisBudgetStore) Pipeline() BudgetPipeline {
+	return &redisBudgetPipeline{pipe: s.client.Pipeline()}
+}
+
+func (s *redisBudgetStore) Ping(ctx context.Context) error {
+	return s.client.Ping(ctx).Err()
+}
+
+func (s *redisBudgetStore) HGetAll(ctx context.Context, key string) (map[string][]byte, error) {
+	raw, err := s.client.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string][]byte, len(raw))
+	for k, v := range raw {
+		fields[k] = []byte(v)
+	}
+	return fields, nil
+}
+
+func (s *redisBudgetStore) HSet(ctx context.Context, key string, fields map[string][]byte, ttl time.Duration) error {
+	args := make([]interface{}, 0, len(fields)*2)
+	for k, v := range fields {
+		args = append(args, k, v)
+	}
+	if err := s.client.HSet(ctx, key, args...).Err(); err != nil {
+		return err
+	}
+	if ttl > 0 {
+		return s.client.Expire(ctx, key, ttl).Err()
+	}
+	return nil
+}
+
+type redisBudgetPipeline struct {
+	pipe redis.Pipeliner
+}
+
+func (p *redisBudgetPipeline) IncrBy(ctx context.Context, key string, value int64) {
+	p.pipe.IncrBy(ctx, key, value)
+}
+
+func (p *redisBudgetPipeline) Expire(ctx context.Context, key string, ttl time.Duration) {
+	p.pipe.Expire(ctx, key, ttl)
+}
+
+func (p *redisBudgetPipeline) Get(ctx context.Context, key string) BudgetStringCmd {
+	return p.pipe.Get(ctx, key)
+}
+
+func (p *redisBudgetPipeline) Set(ctx context.Context, key string, value int64, ttl time.Duration) {
+	p.pipe.Set(ctx, key, value, ttl)
+}
+
+func (p *redisBudgetPipeline) Exec(ctx context.Context) error {
+	_, err := p.pipe.Exec(ctx)
+	if err == redis.Nil {
+		return ErrBudgetKeyNotFound
+	}
+	return err
+}
+
+// memoryStringCmd is the BudgetStringCmd used by both memoryBudgetStore and
+// nullBudgetStore, since neither needs the lazy network-result semantics a
+// real *redis.StringCmd provides.
+type memoryStringCmd struct {
+	val string
+	err error
+}
+
+func (c *memoryStringCmd) Val() string { return c.val }
+func (c *memoryStringCmd) Err() error  { return c.err }
+
+// memoryEntry is one counter tracked by memoryBudgetStore. A zero
+// expiresAt means no TTL has been set yet.
+type memoryEntry struct {
+	value     int64
+	expiresAt time.Time
+}
+
+// memoryHash is one HASH value tracked by memoryBudgetStore.
+type memoryHash struct {
+	fields    map[string][]byte
+	expiresAt time.Time
+}
+
+// memoryBudgetStore is a BudgetStore backed by an in-process map, useful
+// for tests and for single-node deploys that don't need a shared backend.
+type memoryBudgetStore struct {
+	mu     sync.Mutex
+	data   map[string]*memoryEntry
+	hashes map[string]*memoryHash
+}
+
+// NewMemoryBudgetStore builds a BudgetStore that keeps all counters
+// in-process. Expired entries are evicted lazily on access.
+func NewMemoryBudgetStore() BudgetStore {
+	return &memoryBudgetStore{
+		data:   make(map[string]*memoryEntry),
+		hashes: make(map[string]*memoryHash),
+	}
+}
+
+func (s *memoryBudgetStore) Ping(ctx context.Context) error { return nil }
+
+func (s *memoryBudgetStore) Pipeline() BudgetPipeline {
+	return &memoryBudgetPipeline{store: s}
+}
+
+func (s *memoryBudgetStore) HGetAll(ctx context.Context, key string) (map[string][]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	h, ok := s.hashes[key]
+	if !ok {
+		return map[string][]byte{}, nil
+	}
+	if !h.expiresAt.IsZero() && time.Now().After(h.expiresAt) {
+		delete(s.hashes, key)
+		return map[string][]byte{}, nil
+	}
+
+	fields := make(map[string][]byte, len(h.fields))
+	for k, v := range h.fields {
+		fields[k] = v
+	}
+	return fields, nil
+}
+
+func (s *memoryBudgetStore) HSet(ctx context.Context, key string, fields map[string][]byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	h, ok := s.hashes[key]
+	if !ok {
+		h = &memoryHash{fields: make(map[string][]byte, len(fields))}
+		s.hashes[key] = h
+	}
+	for k, v := range fields {
+		h.fields[k] = v
+	}
+	if ttl > 0 {
+		h.expiresAt = time.Now().Add(ttl)
+	}
+	return nil
+}
+
+// memoryOp is one queued pipeline operation, run against now when Exec is
+// called; only Get's op can return a (ErrBudgetKeyNotFound) error.
+type memoryOp func(now time.Time) error
+
+type memoryBudgetPipeline struct {
+	store *memoryBudgetStore
+	ops   []memoryOp
+}
+
+func (p *memoryBudgetPipeline) entry(key string, now time.Time) (*memoryEntry, bool) {
+	e, ok := p.store.data[key]
+	if !ok {
+		return nil, false
+	}
+	if !e.expiresAt.IsZero() && now.After(e.expiresAt) {
+		delete(p.store.data, key)
+		return nil, false
+	}
+	return e, true
+}
+
+func (p *memoryBudgetPipeline) IncrBy(ctx context.Context, key string, value int64) {
+	p.ops = append(p.ops, func(now time.Time) error {
+		p.store.mu.Lock()
+		defer p.store.mu.Unlock()
+
+		e, ok := p.entry(key, now)
+		if !ok {
+			e = &memoryEntry{}
+			p.store.data[key] = e
+		}
+		e.value += value
+		return nil
+	})
+}
+
+func (p *memoryBudgetPipeline) Expire(ctx context.Context, key string, ttl time.Duration) {
+	p.ops = append(p.ops, func(now time.Time) error {
+		p.store.mu.Lock()
+		defer p.store.mu.Unlock()
+
+		if e, ok := p.entry(key, now); ok {
+			e.expiresAt = now.Add(ttl)
+		}
+		return nil
+	})
+}
+
+func (p *memoryBudgetPipeline) Set(ctx context.Context, key string, value int64, ttl time.Duration) {
+	p.ops = append(p.ops, func(now time.Time) error {
+		p.store.mu.Lock()
+		defer p.store.mu.Unlock()
+
+		p.store.data[key] = &memoryEntry{value: value, expiresAt: now.Add(ttl)}
+		return nil
+	})
+}
+
+func (p *memoryBudgetPipeline) Get(ctx context.Context, key string) BudgetStringCmd {
+	cmd := &memoryStringCmd{}
+	p.ops = append(p.ops, func(now time.Time) error {
+		p.store.mu.Lock()
+		defer p.store.mu.Unlock()
+
+		e, ok := p.entry(key, now)
+		if !ok {
+			cmd.err = ErrBudgetKeyNotFound
+			return ErrBudgetKeyNotFound
+		}
+		cmd.val = strconv.FormatInt(e.value, 10)
+		return nil
+	})
+	return cmd
+}
+
+// Exec runs every queued operation in order and returns the first error
+// encountered (a missing Get key, currently the only way one occurs),
+// matching how go-redis's Pipeline.Exec surfaces a cmd-level error.
+func (p *memoryBudgetPipeline) Exec(ctx context.Context) error {
+	now := time.Now()
+	var firstErr error
+	for _, op := range p.ops {
+		if err := op(now); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	p.ops = nil
+	return firstErr
+}
+
+// errNullBudgetStore is returned by every nullBudgetStore operation.
+var errNullBudgetStore = errors.New("budgetstore: null store has no backend")
+
+// nullBudgetStore is a BudgetStore that never succeeds, keeping
+// ResilientBudgetTracker permanently on its in-memory fallback path. Useful
+// for deployments that intentionally run without a shared counter backend
+// and accept memory-fallback's conservative throttling everywhere.
+type nullBudgetStore struct{}
+
+// NewNullBudgetStore builds a BudgetStore with no backend at all.
+func NewNullBudgetStore() BudgetStore { return nullBudgetStore{} }
+
+func (nullBudgetStore) Ping(ctx context.Context) error { return errNullBudgetStore }
+func (nullBudgetStore) Pipeline() BudgetPipeline       { return nullBudgetPipeline{} }
+
+func (nullBudgetStore) HGetAll(ctx context.Context, key string) (map[string][]byte, error) {
+	return nil, errNullBudgetStore
+}
+
+func (nullBudgetStore) HSet(ctx context.Context, key string, fields map[string][]byte, ttl time.Duration) error {
+	return errNullBudgetStore
+}
+
+type nullBudgetPipeline struct{}
+
+func (nullBudgetPipeline) IncrBy(ctx context.Context, key string, value int64)               {}
+func (nullBudgetPipeline) Expire(ctx context.Context, key string, ttl time.Duration)          {}
+func (nullBudgetPipeline) Set(ctx context.Context, key string, value int64, ttl time.Duration) {}
+func (nullBudgetPipeline) Get(ctx context.Context, key string) BudgetStringCmd {
+	return &memoryStringCmd{err: errNullBudgetStore}
+}
+func (nullBudgetPipeline) Exec(ctx context.Context) error { return errNullBudgetStore }
@@ -0,0 +1,80 @@
+package pacer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMemoryBudgetStore_IncrByAndGetRoundTrip(t *testing.T) {
+	store := NewMemoryBudgetStore()
+
+	pipe := store.Pipeline()
+	pipe.IncrBy(context.Background(), "budget:day:camp-1", 100)
+	pipe.IncrBy(context.Background(), "budget:day:camp-1", 50)
+	if err := pipe.Exec(context.Background()); err != nil {
+		t.Fatalf("unexpected Exec error: %v", err)
+	}
+
+	pipe = store.Pipeline()
+	cmd := pipe.Get(context.Background(), "budget:day:camp-1")
+	if err := pipe.Exec(context.Background()); err != nil {
+		t.Fatalf("unexpected Exec error: %v", err)
+	}
+	if cmd.Val() != "150" {
+		t.Fatalf("expected coalesced value 150, got %q", cmd.Val())
+	}
+}
+
+func TestMemoryBudgetStore_GetMissingKeyReturnsErrBudgetKeyNotFound(t *testing.T) {
+	store := NewMemoryBudgetStore()
+
+	pipe := store.Pipeline()
+	cmd := pipe.Get(context.Background(), "budget:day:nonexistent")
+	err := pipe.Exec(context.Background())
+	if !errors.Is(err, ErrBudgetKeyNotFound) {
+		t.Fatalf("expected ErrBudgetKeyNotFound, got %v", err)
+	}
+	if cmd.Val() != "" {
+		t.Fatalf("expected empty value for missing key, got %q", cmd.Val())
+	}
+}
+
+func TestMemoryBudgetStore_ExpireEvictsKeyAfterTTL(t *testing.T) {
+	store := NewMemoryBudgetStore()
+
+	pipe := store.Pipeline()
+	pipe.IncrBy(context.Background(), "budget:hour:camp-1", 10)
+	pipe.Expire(context.Background(), "budget:hour:camp-1", -time.Second) // already expired
+	if err := pipe.Exec(context.Background()); err != nil {
+		t.Fatalf("unexpected Exec error: %v", err)
+	}
+
+	pipe = store.Pipeline()
+	pipe.Get(context.Background(), "budget:hour:camp-1")
+	if err := pipe.Exec(context.Background()); !errors.Is(err, ErrBudgetKeyNotFound) {
+		t.Fatalf("expected expired key to read as not found, got %v", err)
+	}
+}
+
+func TestMemoryBudgetStore_Ping(t *testing.T) {
+	store := NewMemoryBudgetStore()
+	if err := store.Ping(context.Background()); err != nil {
+		t.Fatalf("expected memory store Ping to always succeed, got %v", err)
+	}
+}
+
+func TestNullBudgetStore_AlwaysFails(t *testing.T) {
+	store := NewNullBudgetStore()
+
+	if err := store.Ping(context.Background()); err == nil {
+		t.Fatalf("expected null store Ping to fail")
+	}
+
+	pipe := store.Pipeline()
+	pipe.IncrBy(context.Background(), "budget:day:camp-1", 10)
+	if err := pipe.Exec(context.Background()); err == nil {
+		t.Fatalf("expected null store Exec to fail")
+	}
+}
@@ -3,6 +3,7 @@ package pacer
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -14,134 +15,259 @@ const (
 	CLOSED     CircuitBreakerState = "CLOSED"
 	OPEN       CircuitBreakerState = "OPEN"
 	HALF_OPEN  CircuitBreakerState = "HALF_OPEN"
+	// DOOMED means the campaign is done for the day (e.g. budget exhausted
+	// or sustained terminal failures). Unlike OPEN, it never auto-recovers
+	// via the timeout path and requires an explicit Reset.
+	DOOMED     CircuitBreakerState = "DOOMED"
 )
 
+// stateCode is the atomic-friendly representation of CircuitBreakerState.
+type stateCode int32
+
+const (
+	stateClosed stateCode = iota
+	stateOpen
+	stateHalfOpen
+	stateDoomed
+)
+
+func (s stateCode) public() CircuitBreakerState {
+	switch s {
+	case stateOpen:
+		return OPEN
+	case stateHalfOpen:
+		return HALF_OPEN
+	case stateDoomed:
+		return DOOMED
+	default:
+		return CLOSED
+	}
+}
+
+// budgetAlertThresholds are the spend-percentage milestones that publish a
+// BudgetThresholdCrossed event the first time they're crossed in a day. Bit
+// i of CircuitBreaker.crossedBits tracks whether budgetAlertThresholds[i]
+// has already fired.
+var budgetAlertThresholds = []float64{0.80, 0.90, 0.95}
+
+// CircuitBreaker's hot path (Allow in the common CLOSED case) is lock-free:
+// state, counters, and timestamps are stored as atomics so a bid decision
+// at auction QPS never contends on a mutex. State transitions (trip,
+// half-open probe, recovery, doom) use CompareAndSwap so concurrent
+// goroutines racing to transition produce exactly one log entry and one
+// lastStateChange update; GetState/GetMetrics/Reset remain consistent from
+// the caller's perspective.
 type CircuitBreaker struct {
-	mu              sync.RWMutex
-	state           CircuitBreakerState
-	failureCount    int
-	successCount    int
-	lastFailureTime time.Time
-	lastStateChange time.Time
-	
-	maxFailures     int
-	timeout         time.Duration
-	successThreshold int
-	budgetThreshold float64
+	campaignID string
+
+	state                      atomic.Int32
+	failureCount               atomic.Int64
+	successCount               atomic.Int64
+	lastFailureNanos           atomic.Int64
+	lastStateChangeNanos       atomic.Int64
+	transientFailureSinceNanos atomic.Int64
+	crossedBits                atomic.Int32
+
+	maxFailures       int
+	timeout           time.Duration
+	successThreshold  int
+	budgetThreshold   float64
+	terminalThreshold time.Duration
 }
 
 func NewCircuitBreaker() *CircuitBreaker {
-	return &CircuitBreaker{
-		state:            CLOSED,
-		maxFailures:      3,
-		timeout:          5 * time.Minute,
-		successThreshold: 2,
-		budgetThreshold:  0.95,
+	cb := &CircuitBreaker{
+		maxFailures:       3,
+		timeout:           5 * time.Minute,
+		successThreshold:  2,
+		budgetThreshold:   0.95,
+		terminalThreshold: 10 * time.Minute,
 	}
+	cb.state.Store(int32(stateClosed))
+	return cb
 }
 
 func (cb *CircuitBreaker) Allow(status *BudgetStatus) bool {
-	cb.mu.Lock()
-	defer cb.mu.Unlock()
+	state := stateCode(cb.state.Load())
+	if state == stateDoomed {
+		return false
+	}
 
 	spendPercentage := status.GetSpendPercentage() / 100.0
+	cb.checkBudgetThresholds(spendPercentage)
+
 	if spendPercentage >= cb.budgetThreshold {
-		cb.trip("Budget threshold exceeded")
+		cb.doom("Budget threshold exceeded")
 		return false
 	}
 
 	// Check if too many failures accumulated
-	if cb.failureCount >= cb.maxFailures && cb.state == CLOSED {
+	if state == stateClosed && cb.failureCount.Load() >= int64(cb.maxFailures) {
 		cb.trip("Max failures exceeded")
 		return false
 	}
 
-	switch cb.state {
-	case CLOSED:
+	switch state {
+	case stateClosed:
 		return true
-		
-	case OPEN:
-		if time.Since(cb.lastStateChange) > cb.timeout {
-			cb.state = HALF_OPEN
-			cb.successCount = 0
-			cb.failureCount = 0
-			cb.lastStateChange = time.Now()
-			log.Info("Circuit breaker entering HALF_OPEN state")
+
+	case stateOpen:
+		lastChange := time.Unix(0, cb.lastStateChangeNanos.Load())
+		if time.Since(lastChange) > cb.timeout {
+			if cb.state.CompareAndSwap(int32(stateOpen), int32(stateHalfOpen)) {
+				cb.successCount.Store(0)
+				cb.failureCount.Store(0)
+				cb.lastStateChangeNanos.Store(time.Now().UnixNano())
+				log.Info("Circuit breaker entering HALF_OPEN state")
+			}
 			return true
 		}
 		return false
-		
-	case HALF_OPEN:
-		return cb.successCount < cb.successThreshold
-		
+
+	case stateHalfOpen:
+		return cb.successCount.Load() < int64(cb.successThreshold)
+
 	default:
 		return false
 	}
 }
 
 func (cb *CircuitBreaker) RecordSuccess() {
-	cb.mu.Lock()
-	defer cb.mu.Unlock()
+	cb.failureCount.Store(0)
+	cb.transientFailureSinceNanos.Store(0)
 
-	cb.failureCount = 0
-	
-	if cb.state == HALF_OPEN {
-		cb.successCount++
-		if cb.successCount >= cb.successThreshold {
-			cb.state = CLOSED
-			cb.lastStateChange = time.Now()
-			log.Info("Circuit breaker recovered to CLOSED state")
+	if stateCode(cb.state.Load()) == stateHalfOpen {
+		if cb.successCount.Add(1) >= int64(cb.successThreshold) {
+			if cb.state.CompareAndSwap(int32(stateHalfOpen), int32(stateClosed)) {
+				cb.lastStateChangeNanos.Store(time.Now().UnixNano())
+				log.Info("Circuit breaker recovered to CLOSED state")
+				Publish(Event{Type: EventCircuitBreakerRecovered, CampaignID: cb.campaignID, FromState: HALF_OPEN, ToState: CLOSED})
+			}
 		}
 	}
 }
 
+// RecordFailure records a transient failure. Kept as an alias of
+// RecordTransientFailure for existing callers.
 func (cb *CircuitBreaker) RecordFailure(reason string) {
-	cb.mu.Lock()
-	defer cb.mu.Unlock()
+	cb.RecordTransientFailure(reason)
+}
 
-	cb.failureCount++
-	cb.lastFailureTime = time.Now()
+// RecordTransientFailure records a failure expected to recover on its own
+// (e.g. an auction latency spike). Enough transient failures trip the
+// breaker to OPEN, which auto-recovers after timeout; transient failures
+// observed continuously for terminalThreshold escalate to DOOMED.
+func (cb *CircuitBreaker) RecordTransientFailure(reason string) {
+	state := stateCode(cb.state.Load())
+	if state == stateDoomed {
+		return
+	}
+
+	now := time.Now()
+	if cb.failureCount.Load() == 0 {
+		cb.transientFailureSinceNanos.Store(now.UnixNano())
+	}
+	newCount := cb.failureCount.Add(1)
+	cb.lastFailureNanos.Store(now.UnixNano())
+
+	since := cb.transientFailureSinceNanos.Load()
+	if cb.terminalThreshold > 0 && since != 0 &&
+		now.Sub(time.Unix(0, since)) >= cb.terminalThreshold {
+		cb.doom(reason + " (sustained past terminal threshold)")
+		return
+	}
 
-	if cb.state == HALF_OPEN || cb.failureCount >= cb.maxFailures {
+	if state == stateHalfOpen || newCount >= int64(cb.maxFailures) {
 		cb.trip(reason)
 	}
 }
 
+// RecordTerminalFailure records a failure that the campaign cannot recover
+// from this cycle (e.g. budget exhaustion) and trips the breaker straight
+// into DOOMED, which never auto-recovers via the timeout path.
+func (cb *CircuitBreaker) RecordTerminalFailure(reason string) {
+	cb.lastFailureNanos.Store(time.Now().UnixNano())
+	cb.doom(reason)
+}
+
 func (cb *CircuitBreaker) trip(reason string) {
-	if cb.state != OPEN {
-		cb.state = OPEN
-		cb.lastStateChange = time.Now()
-		cb.successCount = 0
+	old := stateCode(cb.state.Load())
+	if old == stateOpen || old == stateDoomed {
+		return
+	}
+	if cb.state.CompareAndSwap(int32(old), int32(stateOpen)) {
+		cb.lastStateChangeNanos.Store(time.Now().UnixNano())
+		cb.successCount.Store(0)
 		log.WithField("reason", reason).Warn("Circuit breaker tripped to OPEN state")
+		Publish(Event{Type: EventCircuitBreakerTripped, CampaignID: cb.campaignID, Reason: reason, FromState: old.public(), ToState: OPEN})
+	}
+}
+
+// doom trips the breaker into DOOMED. Unlike trip, this never auto-recovers
+// via the timeout path and requires an explicit Reset (or admin API call).
+func (cb *CircuitBreaker) doom(reason string) {
+	old := stateCode(cb.state.Load())
+	if old == stateDoomed {
+		return
+	}
+	if cb.state.CompareAndSwap(int32(old), int32(stateDoomed)) {
+		cb.lastStateChangeNanos.Store(time.Now().UnixNano())
+		cb.successCount.Store(0)
+		log.WithField("reason", reason).Warn("Circuit breaker tripped to DOOMED state")
+		Publish(Event{Type: EventCircuitBreakerTripped, CampaignID: cb.campaignID, Reason: reason, FromState: old.public(), ToState: DOOMED})
+	}
+}
+
+// checkBudgetThresholds publishes BudgetThresholdCrossed the first time
+// spendPercentage (0.0-1.0) crosses each milestone in budgetAlertThresholds
+// for the current day. Crossed milestones are tracked as bits in an atomic
+// int so the common case (no new threshold crossed) never takes a lock.
+func (cb *CircuitBreaker) checkBudgetThresholds(spendPercentage float64) {
+	for i, threshold := range budgetAlertThresholds {
+		bit := int32(1) << uint(i)
+		if spendPercentage < threshold {
+			continue
+		}
+		for {
+			bits := cb.crossedBits.Load()
+			if bits&bit != 0 {
+				break
+			}
+			if cb.crossedBits.CompareAndSwap(bits, bits|bit) {
+				Publish(Event{
+					Type:             EventBudgetThresholdCrossed,
+					CampaignID:       cb.campaignID,
+					ThresholdPercent: threshold * 100,
+				})
+				break
+			}
+		}
 	}
 }
 
 func (cb *CircuitBreaker) GetState() CircuitBreakerState {
-	cb.mu.RLock()
-	defer cb.mu.RUnlock()
-	return cb.state
+	return stateCode(cb.state.Load()).public()
 }
 
 func (cb *CircuitBreaker) Reset() {
-	cb.mu.Lock()
-	defer cb.mu.Unlock()
-	
-	cb.state = CLOSED
-	cb.failureCount = 0
-	cb.successCount = 0
-	cb.lastStateChange = time.Now()
+	cb.state.Store(int32(stateClosed))
+	cb.failureCount.Store(0)
+	cb.successCount.Store(0)
+	cb.lastStateChangeNanos.Store(time.Now().UnixNano())
+	cb.transientFailureSinceNanos.Store(0)
+	cb.crossedBits.Store(0)
 }
 
 func (cb *CircuitBreaker) GetMetrics() map[string]interface{} {
-	cb.mu.RLock()
-	defer cb.mu.RUnlock()
-	
+	state := stateCode(cb.state.Load())
+
 	return map[string]interface{}{
-		"state":            string(cb.state),
-		"failure_count":    cb.failureCount,
-		"success_count":    cb.successCount,
-		"last_failure":     cb.lastFailureTime,
-		"last_state_change": cb.lastStateChange,
+		"state":              string(state.public()),
+		"failure_count":      cb.failureCount.Load(),
+		"success_count":      cb.successCount.Load(),
+		"last_failure":       time.Unix(0, cb.lastFailureNanos.Load()),
+		"last_state_change":  time.Unix(0, cb.lastStateChangeNanos.Load()),
+		"doomed":             state == stateDoomed,
 	}
 }
 
@@ -173,6 +299,7 @@ func (cbm *CircuitBreakerManager) GetBreaker(campaignID string) *CircuitBreaker
 	}
 	
 	breaker = NewCircuitBreaker()
+	breaker.campaignID = campaignID
 	cbm.breakers[campaignID] = breaker
 	return breaker
 }
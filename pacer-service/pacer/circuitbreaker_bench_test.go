@@ -0,0 +1,21 @@
+package pacer
+
+import "testing"
+
+// BenchmarkCircuitBreakerAllow_Parallel exercises the common CLOSED-state
+// path under contention to demonstrate the lock-free fast path in Allow.
+func BenchmarkCircuitBreakerAllow_Parallel(b *testing.B) {
+	cb := NewCircuitBreaker()
+	status := &BudgetStatus{
+		CampaignID:  "bench-campaign",
+		DailyBudget: 10000,
+		DailySpent:  5000,
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			cb.Allow(status)
+		}
+	})
+}
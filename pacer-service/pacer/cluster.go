@@ -0,0 +1,456 @@
+package pacer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	clusterLeaderKey          = "cluster:leader"
+	clusterInstancePrefix     = "cluster:instance:"
+	clusterUpdatesChannel     = "cluster:campaign_updates"
+	clusterStaleHourScanBatch = int64(200)
+
+	heartbeatTTL        = 15 * time.Second
+	heartbeatInterval   = 5 * time.Second
+	leaderLeaseTTL      = 10 * time.Second
+	leaderElectInterval = 3 * time.Second
+	leaderTaskInterval  = 1 * time.Minute
+)
+
+var renewLeaderScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+else
+	return 0
+end
+`)
+
+// CampaignUpdate is the payload broadcast over clusterUpdatesChannel
+// whenever the leader reloads the campaign table, so followers can apply
+// the change locally instead of polling Postgres themselves.
+type CampaignUpdate struct {
+	CampaignID  string                     `json:"campaign_id"`
+	DailyBudget int64                      `json:"daily_budget_cents"`
+	PacingMode  PacingMode                 `json:"pacing_mode"`
+	Status      string                     `json:"status"`
+	Timezone    string                     `json:"timezone"` // IANA name; "" means UTC
+	RateLimiter *CampaignRateLimiterConfig `json:"rate_limiter,omitempty"`
+}
+
+// ClusterTasks are the singleton jobs Cluster runs only on the elected
+// leader (or, for ApplyCampaignUpdate, on every instance), injected so
+// this package doesn't need to import main's Server/Campaign types.
+type ClusterTasks struct {
+	// ResetDailyBudget resets campaignID's daily spend counter.
+	ResetDailyBudget func(ctx context.Context, campaignID string) error
+	// RefreshCampaigns reloads the campaign table from Postgres and
+	// returns the current full set, which the leader broadcasts in full,
+	// one message per campaign.
+	RefreshCampaigns func() ([]CampaignUpdate, error)
+	// ApplyCampaignUpdate applies a campaign change received over cluster
+	// pub/sub to the local in-memory campaign map. Called on every instance
+	// that receives a broadcast (the leader included), sparing followers a
+	// Postgres poll.
+	ApplyCampaignUpdate func(update CampaignUpdate)
+}
+
+// Cluster coordinates multiple pacer-service replicas sharing one Redis:
+// each instance registers a TTL heartbeat, one instance holds a leader
+// lease at a time (SET NX PX, renewed via a compare-and-expire script),
+// and the leader alone runs singleton maintenance -- midnight budget
+// resets per campaign timezone, campaign table refresh, and stale
+// budget:hour:* key cleanup -- broadcasting campaign changes to followers
+// over Redis pub/sub so they invalidate their BudgetTracker cache instead
+// of polling Postgres.
+type Cluster struct {
+	redisClient *redis.Client
+	tracker     *BudgetTracker
+	tasks       ClusterTasks
+	instanceID  string
+
+	mu         sync.RWMutex
+	isLeader   bool
+	lastResets map[string]string // campaignID -> date (YYYY-MM-DD in its timezone) of its last reset
+
+	wg     sync.WaitGroup
+	stopCh chan struct{}
+}
+
+func NewCluster(redisClient *redis.Client, tracker *BudgetTracker, tasks ClusterTasks) *Cluster {
+	return &Cluster{
+		redisClient: redisClient,
+		tracker:     tracker,
+		tasks:       tasks,
+		instanceID:  fmt.Sprintf("pacer-%d-%d", time.Now().UnixNano(), rand.Intn(1_000_000)),
+		lastResets:  make(map[string]string),
+		stopCh:      make(chan struct{}),
+	}
+}
+
+// Start launches the heartbeat, leader election, update subscription, and
+// leader-task loops. Call Stop during graceful shutdown.
+func (c *Cluster) Start() {
+	c.wg.Add(4)
+	go c.heartbeatLoop()
+	go c.leaderElectionLoop()
+	go c.subscribeLoop()
+	go c.leaderTaskLoop()
+}
+
+// Stop ends all background loops and blocks until they exit.
+func (c *Cluster) Stop() {
+	close(c.stopCh)
+	c.wg.Wait()
+}
+
+// IsLeader reports whether this instance currently holds the leader lease.
+func (c *Cluster) IsLeader() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.isLeader
+}
+
+// InstanceID is this process's cluster identity, used as the leader lease
+// value and the heartbeat key suffix.
+func (c *Cluster) InstanceID() string {
+	return c.instanceID
+}
+
+func (c *Cluster) setLeader(leader bool) {
+	c.mu.Lock()
+	changed := c.isLeader != leader
+	c.isLeader = leader
+	c.mu.Unlock()
+
+	if changed {
+		log.WithFields(log.Fields{"instance_id": c.instanceID, "is_leader": leader}).Info("cluster: leadership changed")
+	}
+}
+
+func (c *Cluster) heartbeatLoop() {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+
+	beat := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		key := clusterInstancePrefix + c.instanceID
+		if err := c.redisClient.Set(ctx, key, time.Now().Format(time.RFC3339), heartbeatTTL).Err(); err != nil {
+			log.WithError(err).Warn("cluster: heartbeat failed")
+		}
+	}
+
+	beat()
+	for {
+		select {
+		case <-ticker.C:
+			beat()
+		case <-c.stopCh:
+			return
+		}
+	}
+}
+
+func (c *Cluster) leaderElectionLoop() {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(leaderElectInterval)
+	defer ticker.Stop()
+
+	elect := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		c.setLeader(c.tryAcquireOrRenewLeader(ctx))
+	}
+
+	elect()
+	for {
+		select {
+		case <-ticker.C:
+			elect()
+		case <-c.stopCh:
+			return
+		}
+	}
+}
+
+func (c *Cluster) tryAcquireOrRenewLeader(ctx context.Context) bool {
+	acquired, err := c.redisClient.SetNX(ctx, clusterLeaderKey, c.instanceID, leaderLeaseTTL).Result()
+	if err != nil {
+		log.WithError(err).Warn("cluster: leader election attempt failed")
+		return c.IsLeader()
+	}
+	if acquired {
+		return true
+	}
+
+	renewed, err := renewLeaderScript.Run(ctx, c.redisClient, []string{clusterLeaderKey}, c.instanceID, leaderLeaseTTL.Milliseconds()).Result()
+	if err != nil {
+		log.WithError(err).Warn("cluster: leader lease renewal failed")
+		return false
+	}
+	result, _ := renewed.(int64)
+	return result == 1
+}
+
+// subscribeLoop applies campaign updates published by the leader, so
+// followers don't need to poll Postgres to stay in sync, and invalidates
+// this instance's BudgetTracker cache for the affected campaign.
+func (c *Cluster) subscribeLoop() {
+	defer c.wg.Done()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-c.stopCh
+		cancel()
+	}()
+
+	sub := c.redisClient.Subscribe(ctx, clusterUpdatesChannel)
+	defer sub.Close()
+
+	for {
+		msg, err := sub.ReceiveMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.WithError(err).Warn("cluster: campaign update subscription error")
+			time.Sleep(time.Second)
+			continue
+		}
+
+		var update CampaignUpdate
+		if err := json.Unmarshal([]byte(msg.Payload), &update); err != nil {
+			log.WithError(err).Warn("cluster: failed to decode campaign update")
+			continue
+		}
+
+		if c.tasks.ApplyCampaignUpdate != nil {
+			c.tasks.ApplyCampaignUpdate(update)
+		}
+		c.tracker.invalidateCache(update.CampaignID)
+	}
+}
+
+// leaderTaskLoop runs the singleton maintenance tasks once per tick, but
+// only while this instance holds the leader lease.
+func (c *Cluster) leaderTaskLoop() {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(leaderTaskInterval)
+	defer ticker.Stop()
+
+	run := func() {
+		if !c.IsLeader() {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		updates := c.refreshAndBroadcastCampaigns(ctx)
+		c.resetCampaignsAtLocalMidnight(ctx, updates)
+		c.cleanupStaleHourKeys(ctx)
+	}
+
+	run()
+	for {
+		select {
+		case <-ticker.C:
+			run()
+		case <-c.stopCh:
+			return
+		}
+	}
+}
+
+func (c *Cluster) refreshAndBroadcastCampaigns(ctx context.Context) []CampaignUpdate {
+	if c.tasks.RefreshCampaigns == nil {
+		return nil
+	}
+
+	updates, err := c.tasks.RefreshCampaigns()
+	if err != nil {
+		log.WithError(err).Error("cluster: leader campaign refresh failed")
+		return nil
+	}
+
+	for _, update := range updates {
+		payload, err := json.Marshal(update)
+		if err != nil {
+			continue
+		}
+		if err := c.redisClient.Publish(ctx, clusterUpdatesChannel, payload).Err(); err != nil {
+			log.WithError(err).Warn("cluster: failed to broadcast campaign update")
+		}
+	}
+
+	return updates
+}
+
+// resetCampaignsAtLocalMidnight resets each campaign's daily budget once
+// per local calendar day in its own timezone, tracked by date string so a
+// campaign is never reset twice for the same day even if the leader
+// changes mid-day.
+func (c *Cluster) resetCampaignsAtLocalMidnight(ctx context.Context, campaigns []CampaignUpdate) {
+	if c.tasks.ResetDailyBudget == nil {
+		return
+	}
+
+	for _, campaign := range campaigns {
+		loc := time.UTC
+		if campaign.Timezone != "" {
+			if l, err := time.LoadLocation(campaign.Timezone); err == nil {
+				loc = l
+			}
+		}
+
+		today := time.Now().In(loc).Format("2006-01-02")
+
+		c.mu.Lock()
+		alreadyResetToday := c.lastResets[campaign.CampaignID] == today
+		c.mu.Unlock()
+
+		if alreadyResetToday {
+			continue
+		}
+		if err := c.tasks.ResetDailyBudget(ctx, campaign.CampaignID); err != nil {
+			log.WithError(err).WithField("campaign_id", campaign.CampaignID).Error("cluster: daily budget reset failed")
+			continue
+		}
+
+		// Only record today's reset once it actually succeeds, so a
+		// transient failure gets retried on the next tick instead of
+		// silently starving the campaign of a reset for the rest of the day.
+		c.mu.Lock()
+		c.lastResets[campaign.CampaignID] = today
+		c.mu.Unlock()
+	}
+}
+
+// cleanupStaleHourKeys deletes budget:hour:* keys whose hour bucket is
+// older than the hour three hours before now. Redis TTLs already expire these eventually; this is a
+// belt-and-suspenders sweep for keys whose TTL was lost (e.g. a RENAME or
+// a restore from an RDB snapshot without TTLs).
+func (c *Cluster) cleanupStaleHourKeys(ctx context.Context) {
+	cutoff := time.Now().Add(-3 * time.Hour).Format("2006-01-02-15")
+
+	var cursor uint64
+	deleted := 0
+	for {
+		keys, next, err := c.redisClient.Scan(ctx, cursor, "budget:hour:*", clusterStaleHourScanBatch).Result()
+		if err != nil {
+			log.WithError(err).Warn("cluster: stale hour-key scan failed")
+			return
+		}
+
+		for _, key := range keys {
+			if isStaleHourKey(key, cutoff) {
+				if err := c.redisClient.Del(ctx, key).Err(); err == nil {
+					deleted++
+				}
+			}
+		}
+
+		cursor = next
+		if cursor == 0 {
+			break
+		}
+	}
+
+	if deleted > 0 {
+		log.WithField("count", deleted).Info("cluster: cleaned up stale budget:hour keys")
+	}
+}
+
+// isStaleHourKey reports whether key's embedded "YYYY-MM-DD-HH" hour
+// bucket sorts before cutoff.
+func isStaleHourKey(key, cutoff string) bool {
+	const prefixLen = len("budget:hour:")
+	if len(key) <= prefixLen {
+		return false
+	}
+	rest := key[prefixLen:]
+	idx := lastColonIndex(rest)
+	if idx < 0 {
+		return false
+	}
+	hourBucket := rest[idx+1:]
+	return hourBucket < cutoff
+}
+
+func lastColonIndex(s string) int {
+	for i := len(s) - 1; i >= 0; i-- {
+		if s[i] == ':' {
+			return i
+		}
+	}
+	return -1
+}
+
+// InstanceStatus describes one heartbeating instance for /cluster/status.
+type InstanceStatus struct {
+	InstanceID string `json:"instance_id"`
+	LastSeen   string `json:"last_seen"`
+}
+
+// ClusterStatus is the /cluster/status response shape.
+type ClusterStatus struct {
+	InstanceID string           `json:"instance_id"`
+	IsLeader   bool             `json:"is_leader"`
+	LeaderID   string           `json:"leader_id"`
+	Instances  []InstanceStatus `json:"instances"`
+}
+
+// Status gathers the current leader and known instance list from Redis
+// for the /cluster/status endpoint.
+func (c *Cluster) Status(ctx context.Context) (ClusterStatus, error) {
+	status := ClusterStatus{
+		InstanceID: c.instanceID,
+		IsLeader:   c.IsLeader(),
+	}
+
+	leaderID, err := c.redisClient.Get(ctx, clusterLeaderKey).Result()
+	if err != nil && err != redis.Nil {
+		return status, err
+	}
+	status.LeaderID = leaderID
+
+	var cursor uint64
+	for {
+		keys, next, err := c.redisClient.Scan(ctx, cursor, clusterInstancePrefix+"*", clusterStaleHourScanBatch).Result()
+		if err != nil {
+			return status, err
+		}
+		for _, key := range keys {
+			lastSeen, err := c.redisClient.Get(ctx, key).Result()
+			if err != nil {
+				continue
+			}
+			status.Instances = append(status.Instances, InstanceStatus{
+				InstanceID: key[len(clusterInstancePrefix):],
+				LastSeen:   lastSeen,
+			})
+		}
+		cursor = next
+		if cursor == 0 {
+			break
+		}
+	}
+
+	sort.Slice(status.Instances, func(i, j int) bool {
+		return status.Instances[i].InstanceID < status.Instances[j].InstanceID
+	})
+
+	return status, nil
+}
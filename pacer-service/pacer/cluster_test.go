@@ -0,0 +1,89 @@
+package pacer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestIsStaleHourKey(t *testing.T) {
+	cutoff := "2026-07-25-10"
+
+	cases := []struct {
+		key  string
+		want bool
+	}{
+		{"budget:hour:camp-1:2026-07-25-09", true},
+		{"budget:hour:camp-1:2026-07-25-11", false},
+		{"budget:hour:camp-1:2026-07-24-23", true},
+		{"budget:hour:camp-1:2026-07-25-10", false},
+		{"not-a-budget-key", false},
+	}
+
+	for _, c := range cases {
+		if got := isStaleHourKey(c.key, cutoff); got != c.want {
+			t.Errorf("isStaleHourKey(%q, %q) = %v, want %v", c.key, cutoff, got, c.want)
+		}
+	}
+}
+
+func TestCluster_ResetCampaignsAtLocalMidnight_SkipsSameDayTwice(t *testing.T) {
+	resetCalls := 0
+	c := &Cluster{
+		lastResets: make(map[string]string),
+		tasks: ClusterTasks{
+			ResetDailyBudget: func(ctx context.Context, campaignID string) error {
+				resetCalls++
+				return nil
+			},
+		},
+	}
+
+	campaigns := []CampaignUpdate{{CampaignID: "camp-1", Timezone: "UTC"}}
+
+	c.resetCampaignsAtLocalMidnight(context.Background(), campaigns)
+	if resetCalls != 1 {
+		t.Fatalf("expected 1 reset on first call, got %d", resetCalls)
+	}
+
+	c.resetCampaignsAtLocalMidnight(context.Background(), campaigns)
+	if resetCalls != 1 {
+		t.Fatalf("expected reset to be skipped the same day, got %d total calls", resetCalls)
+	}
+}
+
+func TestCluster_ResetCampaignsAtLocalMidnight_RetriesAfterFailure(t *testing.T) {
+	resetCalls := 0
+	succeed := false
+	c := &Cluster{
+		lastResets: make(map[string]string),
+		tasks: ClusterTasks{
+			ResetDailyBudget: func(ctx context.Context, campaignID string) error {
+				resetCalls++
+				if !succeed {
+					return fmt.Errorf("transient store failure")
+				}
+				return nil
+			},
+		},
+	}
+
+	campaigns := []CampaignUpdate{{CampaignID: "camp-1", Timezone: "UTC"}}
+
+	c.resetCampaignsAtLocalMidnight(context.Background(), campaigns)
+	if resetCalls != 1 {
+		t.Fatalf("expected 1 attempt after the first failure, got %d", resetCalls)
+	}
+	if _, recorded := c.lastResets["camp-1"]; recorded {
+		t.Fatal("expected a failed reset to not be recorded, so it's retried on the next tick")
+	}
+
+	succeed = true
+	c.resetCampaignsAtLocalMidnight(context.Background(), campaigns)
+	if resetCalls != 2 {
+		t.Fatalf("expected the next tick to retry the failed reset, got %d total calls", resetCalls)
+	}
+	if _, recorded := c.lastResets["camp-1"]; !recorded {
+		t.Fatal("expected a successful reset to be recorded")
+	}
+}
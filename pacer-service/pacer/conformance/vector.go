@@ -0,0 +1,83 @@
+// Package conformance provides a declarative test-vector harness for
+// pacer.PacingAlgorithm implementations: a Vector describes a synthetic
+// day of traffic, and Run simulates it hour-by-hour against an algorithm,
+// asserting the resulting spend curve matches ExpectedSpendCurve within
+// Tolerance. This gives new pacing implementations a shared correctness
+// bar instead of ad-hoc unit tests per algorithm.
+package conformance
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ad-budget-pacer/pacer-service/pacer"
+)
+
+// Vector describes one synthetic day of traffic and the spend curve a
+// conforming PacingAlgorithm is expected to produce.
+type Vector struct {
+	Name                string
+	Mode                pacer.PacingMode
+	DailyBudget         int64
+	HourlyArrivals      [24]int
+	BidCents            int64
+	ExpectedSpendCurve  [24]int64 // cumulative daily spend at the end of each hour
+	Tolerance           float64   // fractional tolerance, e.g. 0.1 for +/-10%
+}
+
+// Run simulates v hour-by-hour against algo, feeding each hour's allowed
+// bids back into a synthetic BudgetStatus, and fails t if the end-of-hour
+// cumulative spend drifts from v.ExpectedSpendCurve by more than
+// v.Tolerance.
+func Run(t *testing.T, v Vector, algo pacer.PacingAlgorithm) {
+	t.Helper()
+
+	var dailySpent int64
+
+	for hour := 0; hour < 24; hour++ {
+		var hourlySpent int64
+
+		for bid := 0; bid < v.HourlyArrivals[hour]; bid++ {
+			status := &pacer.BudgetStatus{
+				CampaignID:     v.Name,
+				DailyBudget:    v.DailyBudget,
+				DailySpent:     dailySpent,
+				HourlyBudget:   v.DailyBudget / 24,
+				HourlySpent:    hourlySpent,
+				RemainingHours: 24 - hour,
+				CurrentHour:    hour,
+				PacingMode:     v.Mode,
+			}
+			status.ThrottleRate = algo.CalculateThrottle(status)
+
+			if !algo.ShouldBid(status) {
+				continue
+			}
+
+			remaining := v.DailyBudget - dailySpent
+			maxBid := algo.GetMaxBid(remaining, v.BidCents)
+			if maxBid <= 0 {
+				continue
+			}
+
+			spend := v.BidCents
+			if spend > maxBid {
+				spend = maxBid
+			}
+
+			dailySpent += spend
+			hourlySpent += spend
+		}
+
+		expected := v.ExpectedSpendCurve[hour]
+		tolerance := v.Tolerance
+		if tolerance <= 0 {
+			tolerance = 0.1
+		}
+
+		allowed := float64(expected) * tolerance
+		if math.Abs(float64(dailySpent-expected)) > allowed {
+			t.Errorf("%s: hour %d cumulative spend = %d, want %d +/- %.0f%%", v.Name, hour, dailySpent, expected, tolerance*100)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package pacer
+
+import (
+	"sync"
+	"time"
+)
+
+// EventType identifies a kind of pacing lifecycle event.
+type EventType string
+
+const (
+	EventBidAllowed             EventType = "BidAllowed"
+	EventBidThrottled           EventType = "BidThrottled"
+	EventCircuitBreakerTripped  EventType = "CircuitBreakerTripped"
+	EventCircuitBreakerRecovered EventType = "CircuitBreakerRecovered"
+	EventBudgetThresholdCrossed EventType = "BudgetThresholdCrossed"
+	EventSpendTracked           EventType = "SpendTracked"
+	EventPacingModeChanged      EventType = "PacingModeChanged"
+)
+
+// Event is a single lifecycle notification. Fields beyond Type, CampaignID,
+// and Time are populated according to the event: e.g. SpendCents for
+// SpendTracked, ThresholdPercent for BudgetThresholdCrossed.
+type Event struct {
+	Type       EventType
+	CampaignID string
+	Time       time.Time
+
+	SpendCents       int64
+	ThresholdPercent float64
+	Reason           string
+	FromState        CircuitBreakerState
+	ToState          CircuitBreakerState
+	PacingMode       PacingMode
+}
+
+// EventHandler receives published events. Handlers are invoked
+// synchronously on the publishing goroutine, so slow handlers (e.g. a
+// webhook subscriber) should hand off to their own goroutine.
+type EventHandler func(Event)
+
+// UnsubscribeFunc removes the handler it was returned for. Calling it more
+// than once is a no-op.
+type UnsubscribeFunc func()
+
+// subscription pairs a handler with an id so it can be found and removed
+// from its slice again by UnsubscribeFunc, without handlers needing to be
+// comparable.
+type subscription struct {
+	id      uint64
+	handler EventHandler
+}
+
+// EventBus is a simple typed pub/sub dispatcher for pacing lifecycle
+// events.
+type EventBus struct {
+	mu          sync.RWMutex
+	nextID      uint64
+	handlers    map[EventType][]subscription
+	allHandlers []subscription
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{handlers: make(map[EventType][]subscription)}
+}
+
+// Subscribe registers handler for events of the given type only. Call the
+// returned UnsubscribeFunc to remove it, e.g. when a long-lived caller
+// (a streaming RPC, a per-connection handler) ends.
+func (b *EventBus) Subscribe(eventType EventType, handler EventHandler) UnsubscribeFunc {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.handlers == nil {
+		b.handlers = make(map[EventType][]subscription)
+	}
+	b.nextID++
+	id := b.nextID
+	b.handlers[eventType] = append(b.handlers[eventType], subscription{id: id, handler: handler})
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.handlers[eventType] = removeSubscription(b.handlers[eventType], id)
+	}
+}
+
+// SubscribeAll registers handler for every event type. Call the returned
+// UnsubscribeFunc to remove it, e.g. when a long-lived caller (a streaming
+// RPC, a per-connection handler) ends.
+func (b *EventBus) SubscribeAll(handler EventHandler) UnsubscribeFunc {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.nextID++
+	id := b.nextID
+	b.allHandlers = append(b.allHandlers, subscription{id: id, handler: handler})
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.allHandlers = removeSubscription(b.allHandlers, id)
+	}
+}
+
+// removeSubscription returns subs with the entry matching id dropped,
+// preserving order of the rest.
+func removeSubscription(subs []subscription, id uint64) []subscription {
+	for i, s := range subs {
+		if s.id == id {
+			return append(subs[:i:i], subs[i+1:]...)
+		}
+	}
+	return subs
+}
+
+// Publish dispatches event to every matching subscriber.
+func (b *EventBus) Publish(event Event) {
+	b.mu.RLock()
+	specific := append([]subscription(nil), b.handlers[event.Type]...)
+	all := append([]subscription(nil), b.allHandlers...)
+	b.mu.RUnlock()
+
+	for _, s := range specific {
+		s.handler(event)
+	}
+	for _, s := range all {
+		s.handler(event)
+	}
+}
+
+// defaultBus is the package-level bus used by pacer.Subscribe,
+// pacer.SubscribeAll, and every internal publisher (CircuitBreaker,
+// handlePacingDecision, handleSpendTrack).
+var defaultBus = NewEventBus()
+
+// Subscribe registers handler on the package-level event bus for
+// eventType, so integrators can react to breaker trips, threshold
+// crossings, etc. without polling /budget/status/. Call the returned
+// UnsubscribeFunc to remove handler again.
+func Subscribe(eventType EventType, handler EventHandler) UnsubscribeFunc {
+	return defaultBus.Subscribe(eventType, handler)
+}
+
+// SubscribeAll registers handler on the package-level event bus for every
+// event type. Call the returned UnsubscribeFunc to remove handler again.
+func SubscribeAll(handler EventHandler) UnsubscribeFunc {
+	return defaultBus.SubscribeAll(handler)
+}
+
+// Publish dispatches event on the package-level event bus.
+func Publish(event Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	defaultBus.Publish(event)
+}
@@ -0,0 +1,56 @@
+package pacer
+
+import "testing"
+
+func TestEventBus_SubscribeDeliversOnlyMatchingType(t *testing.T) {
+	bus := NewEventBus()
+
+	var allowed, throttled int
+	bus.Subscribe(EventBidAllowed, func(e Event) { allowed++ })
+	bus.Subscribe(EventBidThrottled, func(e Event) { throttled++ })
+
+	bus.Publish(Event{Type: EventBidAllowed, CampaignID: "camp-1"})
+	bus.Publish(Event{Type: EventBidAllowed, CampaignID: "camp-1"})
+	bus.Publish(Event{Type: EventBidThrottled, CampaignID: "camp-1"})
+
+	if allowed != 2 {
+		t.Errorf("expected 2 BidAllowed deliveries, got %d", allowed)
+	}
+	if throttled != 1 {
+		t.Errorf("expected 1 BidThrottled delivery, got %d", throttled)
+	}
+}
+
+func TestEventBus_SubscribeAllReceivesEverything(t *testing.T) {
+	bus := NewEventBus()
+
+	var total int
+	bus.SubscribeAll(func(e Event) { total++ })
+
+	bus.Publish(Event{Type: EventBidAllowed})
+	bus.Publish(Event{Type: EventSpendTracked})
+
+	if total != 2 {
+		t.Errorf("expected SubscribeAll handler to see both events, got %d", total)
+	}
+}
+
+func TestEventBus_UnsubscribeStopsDelivery(t *testing.T) {
+	bus := NewEventBus()
+
+	var typed, all int
+	unsubTyped := bus.Subscribe(EventBidAllowed, func(e Event) { typed++ })
+	unsubAll := bus.SubscribeAll(func(e Event) { all++ })
+
+	bus.Publish(Event{Type: EventBidAllowed})
+	unsubTyped()
+	unsubAll()
+	bus.Publish(Event{Type: EventBidAllowed})
+
+	if typed != 1 {
+		t.Errorf("expected 1 typed delivery before unsubscribe, got %d", typed)
+	}
+	if all != 1 {
+		t.Errorf("expected 1 SubscribeAll delivery before unsubscribe, got %d", all)
+	}
+}
@@ -0,0 +1,116 @@
+package pacer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+// NewPrometheusEventSubscriber returns an EventHandler that increments a
+// counter labeled by event type and campaign ID for every published event.
+func NewPrometheusEventSubscriber() EventHandler {
+	counter := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "pacer_lifecycle_events_total",
+			Help: "Count of pacing lifecycle events by type and campaign",
+		},
+		[]string{"event_type", "campaign_id"},
+	)
+	prometheus.MustRegister(counter)
+
+	return func(event Event) {
+		counter.WithLabelValues(string(event.Type), event.CampaignID).Inc()
+	}
+}
+
+// JSONLinesEventSubscriber appends one JSON object per line to a file, for
+// offline analysis or tailing with `jq`.
+type JSONLinesEventSubscriber struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewJSONLinesEventSubscriber opens path for appending and returns a
+// subscriber writing every event there as a JSON line.
+func NewJSONLinesEventSubscriber(path string) (*JSONLinesEventSubscriber, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event log %q: %w", path, err)
+	}
+	return &JSONLinesEventSubscriber{file: f}, nil
+}
+
+func (s *JSONLinesEventSubscriber) Handle(event Event) {
+	line, err := json.Marshal(event)
+	if err != nil {
+		log.WithError(err).Error("Failed to marshal event for JSON-lines subscriber")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.file.Write(append(line, '\n')); err != nil {
+		log.WithError(err).Error("Failed to write event to JSON-lines subscriber")
+	}
+}
+
+func (s *JSONLinesEventSubscriber) Close() error {
+	return s.file.Close()
+}
+
+// WebhookEventSubscriber POSTs each event as JSON to a configured URL,
+// retrying a bounded number of times on failure.
+type WebhookEventSubscriber struct {
+	URL        string
+	MaxRetries int
+	RetryDelay time.Duration
+	Client     *http.Client
+}
+
+// NewWebhookEventSubscriber returns a subscriber that POSTs events to url
+// with up to maxRetries retries spaced retryDelay apart.
+func NewWebhookEventSubscriber(url string, maxRetries int, retryDelay time.Duration) *WebhookEventSubscriber {
+	return &WebhookEventSubscriber{
+		URL:        url,
+		MaxRetries: maxRetries,
+		RetryDelay: retryDelay,
+		Client:     &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (s *WebhookEventSubscriber) Handle(event Event) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		log.WithError(err).Error("Failed to marshal event for webhook subscriber")
+		return
+	}
+
+	go s.postWithRetry(body)
+}
+
+func (s *WebhookEventSubscriber) postWithRetry(body []byte) {
+	attempts := s.MaxRetries + 1
+	for attempt := 1; attempt <= attempts; attempt++ {
+		resp, err := s.Client.Post(s.URL, "application/json", bytes.NewReader(body))
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode < 300 {
+				return
+			}
+			err = fmt.Errorf("webhook returned status %d", resp.StatusCode)
+		}
+
+		if attempt == attempts {
+			log.WithError(err).WithField("url", s.URL).Warn("Webhook event delivery failed, giving up")
+			return
+		}
+		time.Sleep(s.RetryDelay)
+	}
+}
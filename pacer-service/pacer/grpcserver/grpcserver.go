@@ -0,0 +1,414 @@
+// Package grpcserver implements the PacerService RPCs described in
+// proto/pacer.proto (Decide, TrackSpend, BatchTrackSpend, GetStatus, and
+// the bidirectional DecideStream) on top of the same BudgetTracker and
+// CircuitBreakerManager the REST handlers in main.go use.
+//
+// This checkout has no protoc/protoc-gen-go-grpc available, so the
+// request/response types below are hand-written structs matching
+// proto/pacer.proto rather than protoc-generated code, and the server is
+// registered with grpc.ForceServerCodec(jsonCodec{}) so it can serialize
+// them without the generated proto.Message implementations. Once codegen
+// runs in CI, swap these structs for the generated pacerpb package and
+// drop the JSON codec in favor of the default binary one; the Service
+// methods below should need no changes since they only depend on field
+// shapes, not on proto.Message.
+package grpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ad-budget-pacer/pacer-service/pacer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+type DecideRequest struct {
+	CampaignID string `json:"campaign_id"`
+	BidCents   int64  `json:"bid_cents"`
+}
+
+type DecideResponse struct {
+	AllowBid     bool    `json:"allow_bid"`
+	MaxBidCents  int64   `json:"max_bid_cents"`
+	ThrottleRate float64 `json:"throttle_rate"`
+	Reason       string  `json:"reason"`
+}
+
+type TrackSpendRequest struct {
+	CampaignID  string `json:"campaign_id"`
+	SpendCents  int64  `json:"spend_cents"`
+	Impressions int32  `json:"impressions"`
+}
+
+type TrackSpendResponse struct {
+	Success bool `json:"success"`
+}
+
+type BatchTrackSpendRequest struct {
+	SpendCentsByCampaign map[string]int64 `json:"spend_cents_by_campaign"`
+}
+
+type BatchTrackSpendResponse struct {
+	Success bool `json:"success"`
+}
+
+type GetStatusRequest struct {
+	CampaignID string `json:"campaign_id"`
+}
+
+type GetStatusResponse struct {
+	DailyBudgetCents   int64   `json:"daily_budget_cents"`
+	DailySpentCents    int64   `json:"daily_spent_cents"`
+	HourlySpentCents   int64   `json:"hourly_spent_cents"`
+	PacePercentage     float64 `json:"pace_percentage"`
+	CircuitBreakerOpen bool    `json:"circuit_breaker_open"`
+}
+
+// CampaignLookup resolves a campaign ID to its daily budget and pacing
+// mode, mirroring main.Server.campaigns without importing package main.
+type CampaignLookup func(campaignID string) (dailyBudgetCents int64, mode pacer.PacingMode, ok bool)
+
+// Service implements the PacerService RPCs against the shared tracker and
+// circuit breaker manager the REST API uses.
+type Service struct {
+	Tracker        *pacer.BudgetTracker
+	CircuitBreaker *pacer.CircuitBreakerManager
+	Campaigns      CampaignLookup
+}
+
+func (s *Service) Decide(ctx context.Context, req *DecideRequest) (*DecideResponse, error) {
+	dailyBudget, mode, ok := s.Campaigns(req.CampaignID)
+	if !ok {
+		return &DecideResponse{AllowBid: false, Reason: "campaign_not_found"}, nil
+	}
+
+	status, err := s.Tracker.GetBudgetStatus(ctx, req.CampaignID, dailyBudget)
+	if err != nil {
+		return nil, fmt.Errorf("get budget status: %w", err)
+	}
+	status.PacingMode = mode
+
+	if !s.CircuitBreaker.CheckAndTrip(ctx, status) {
+		return &DecideResponse{AllowBid: false, Reason: "circuit_breaker_open"}, nil
+	}
+
+	algo := pacer.GetPacingAlgorithm(mode)
+	throttleRate := algo.CalculateThrottle(status)
+	shouldBid := algo.ShouldBid(status)
+
+	remaining := dailyBudget - status.DailySpent
+	maxBid := algo.GetMaxBid(remaining, req.BidCents)
+	if maxBid < req.BidCents && shouldBid {
+		shouldBid = maxBid > 0
+	}
+
+	reason := "within_budget"
+	if !shouldBid {
+		switch {
+		case status.CircuitBreakerOn:
+			reason = "circuit_breaker"
+		case remaining <= 0:
+			reason = "budget_exhausted"
+		default:
+			reason = "throttled"
+		}
+	}
+
+	return &DecideResponse{
+		AllowBid:     shouldBid,
+		MaxBidCents:  maxBid,
+		ThrottleRate: throttleRate,
+		Reason:       reason,
+	}, nil
+}
+
+func (s *Service) TrackSpend(ctx context.Context, req *TrackSpendRequest) (*TrackSpendResponse, error) {
+	if err := s.Tracker.TrackSpend(ctx, req.CampaignID, req.SpendCents); err != nil {
+		return nil, err
+	}
+	pacer.Publish(pacer.Event{Type: pacer.EventSpendTracked, CampaignID: req.CampaignID, SpendCents: req.SpendCents})
+	return &TrackSpendResponse{Success: true}, nil
+}
+
+func (s *Service) BatchTrackSpend(ctx context.Context, req *BatchTrackSpendRequest) (*BatchTrackSpendResponse, error) {
+	if err := s.Tracker.BatchTrackSpend(ctx, req.SpendCentsByCampaign); err != nil {
+		return nil, err
+	}
+	return &BatchTrackSpendResponse{Success: true}, nil
+}
+
+func (s *Service) GetStatus(ctx context.Context, req *GetStatusRequest) (*GetStatusResponse, error) {
+	dailyBudget, _, ok := s.Campaigns(req.CampaignID)
+	if !ok {
+		return nil, fmt.Errorf("campaign %s not found", req.CampaignID)
+	}
+
+	status, err := s.Tracker.GetBudgetStatus(ctx, req.CampaignID, dailyBudget)
+	if err != nil {
+		return nil, err
+	}
+
+	breaker := s.CircuitBreaker.GetBreaker(req.CampaignID)
+
+	return &GetStatusResponse{
+		DailyBudgetCents:   dailyBudget,
+		DailySpentCents:    status.DailySpent,
+		HourlySpentCents:   status.HourlySpent,
+		PacePercentage:     status.GetSpendPercentage(),
+		CircuitBreakerOpen: breaker.GetState() == pacer.OPEN,
+	}, nil
+}
+
+// decideStreamServer is the subset of grpc.ServerStream DecideStream needs;
+// satisfied by the generated pacerpb.PacerService_DecideStreamServer once
+// codegen is in place. decideStreamWrapper already embeds
+// grpc.ServerStream, so it satisfies Context() for free.
+type decideStreamServer interface {
+	Send(*DecideResponse) error
+	Recv() (*DecideRequest, error)
+	Context() context.Context
+}
+
+// DecideStream serves a single long-lived bidder sidecar connection. It
+// keeps a local cache of BudgetStatus per campaign (same TTL as
+// BudgetTracker's own cache) so repeat decisions for a hot campaign don't
+// round-trip to Redis, invalidating early on SpendTracked/PacingModeChanged
+// events for campaigns this stream has touched.
+func (s *Service) DecideStream(stream decideStreamServer) error {
+	cache := &streamCache{ttl: 5 * time.Second, entries: make(map[string]*cachedStatus)}
+
+	unsubscribe := pacer.SubscribeAll(func(ev pacer.Event) {
+		switch ev.Type {
+		case pacer.EventSpendTracked, pacer.EventPacingModeChanged:
+			cache.invalidate(ev.CampaignID)
+		}
+	})
+	defer unsubscribe()
+
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		resp, err := s.decideFromCache(stream.Context(), cache, req)
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Service) decideFromCache(ctx context.Context, cache *streamCache, req *DecideRequest) (*DecideResponse, error) {
+	dailyBudget, mode, ok := s.Campaigns(req.CampaignID)
+	if !ok {
+		return &DecideResponse{AllowBid: false, Reason: "campaign_not_found"}, nil
+	}
+
+	status, err := cache.get(req.CampaignID)
+	if err != nil {
+		return nil, err
+	}
+	if status == nil {
+		status, err = s.Tracker.GetBudgetStatus(ctx, req.CampaignID, dailyBudget)
+		if err != nil {
+			return nil, fmt.Errorf("get budget status: %w", err)
+		}
+		cache.put(req.CampaignID, status)
+	}
+	status.PacingMode = mode
+
+	if !s.CircuitBreaker.CheckAndTrip(ctx, status) {
+		return &DecideResponse{AllowBid: false, Reason: "circuit_breaker_open"}, nil
+	}
+
+	algo := pacer.GetPacingAlgorithm(mode)
+	throttleRate := algo.CalculateThrottle(status)
+	shouldBid := algo.ShouldBid(status)
+
+	remaining := dailyBudget - status.DailySpent
+	maxBid := algo.GetMaxBid(remaining, req.BidCents)
+	if maxBid < req.BidCents && shouldBid {
+		shouldBid = maxBid > 0
+	}
+
+	return &DecideResponse{
+		AllowBid:     shouldBid,
+		MaxBidCents:  maxBid,
+		ThrottleRate: throttleRate,
+	}, nil
+}
+
+// cachedStatus pairs a BudgetStatus with the time it was fetched, so
+// streamCache can expire it the same way BudgetTracker's own cache does.
+type cachedStatus struct {
+	status  *pacer.BudgetStatus
+	fetched time.Time
+}
+
+// streamCache is a per-connection BudgetStatus cache for DecideStream.
+// It is not shared across streams, so it needs no cross-goroutine
+// coordination beyond guarding its own map.
+type streamCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]*cachedStatus
+}
+
+func (c *streamCache) get(campaignID string) (*pacer.BudgetStatus, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[campaignID]
+	if !ok || time.Since(entry.fetched) >= c.ttl {
+		return nil, nil
+	}
+	return entry.status, nil
+}
+
+func (c *streamCache) put(campaignID string, status *pacer.BudgetStatus) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[campaignID] = &cachedStatus{status: status, fetched: time.Now()}
+}
+
+func (c *streamCache) invalidate(campaignID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, campaignID)
+}
+
+// jsonCodec lets the gRPC server and client exchange the plain structs
+// above without generated proto.Message implementations. Drop this in
+// favor of the default binary codec once proto/pacer.proto has real
+// protoc-generated bindings.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error { return json.Unmarshal(data, v) }
+func (jsonCodec) Name() string { return "json" }
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+// NewServer builds a *grpc.Server with svc registered under the
+// PacerService name used in proto/pacer.proto, using the JSON codec
+// described above.
+func NewServer(svc *Service) *grpc.Server {
+	srv := grpc.NewServer(grpc.ForceServerCodec(jsonCodec{}))
+	srv.RegisterService(&serviceDesc, svc)
+	return srv
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pacer.PacerService",
+	HandlerType: (*any)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "Decide", Handler: decideHandler},
+		{MethodName: "TrackSpend", Handler: trackSpendHandler},
+		{MethodName: "BatchTrackSpend", Handler: batchTrackSpendHandler},
+		{MethodName: "GetStatus", Handler: getStatusHandler},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "DecideStream",
+			Handler:       decideStreamHandler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "proto/pacer.proto",
+}
+
+func decideHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	req := new(DecideRequest)
+	if err := dec(req); err != nil {
+		return nil, err
+	}
+	svc := srv.(*Service)
+	if interceptor == nil {
+		return svc.Decide(ctx, req)
+	}
+	info := &grpc.UnaryServerInfo{Server: svc, FullMethod: "/pacer.PacerService/Decide"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return svc.Decide(ctx, req.(*DecideRequest))
+	}
+	return interceptor(ctx, req, info, handler)
+}
+
+func trackSpendHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	req := new(TrackSpendRequest)
+	if err := dec(req); err != nil {
+		return nil, err
+	}
+	svc := srv.(*Service)
+	if interceptor == nil {
+		return svc.TrackSpend(ctx, req)
+	}
+	info := &grpc.UnaryServerInfo{Server: svc, FullMethod: "/pacer.PacerService/TrackSpend"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return svc.TrackSpend(ctx, req.(*TrackSpendRequest))
+	}
+	return interceptor(ctx, req, info, handler)
+}
+
+func batchTrackSpendHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	req := new(BatchTrackSpendRequest)
+	if err := dec(req); err != nil {
+		return nil, err
+	}
+	svc := srv.(*Service)
+	if interceptor == nil {
+		return svc.BatchTrackSpend(ctx, req)
+	}
+	info := &grpc.UnaryServerInfo{Server: svc, FullMethod: "/pacer.PacerService/BatchTrackSpend"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return svc.BatchTrackSpend(ctx, req.(*BatchTrackSpendRequest))
+	}
+	return interceptor(ctx, req, info, handler)
+}
+
+func getStatusHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	req := new(GetStatusRequest)
+	if err := dec(req); err != nil {
+		return nil, err
+	}
+	svc := srv.(*Service)
+	if interceptor == nil {
+		return svc.GetStatus(ctx, req)
+	}
+	info := &grpc.UnaryServerInfo{Server: svc, FullMethod: "/pacer.PacerService/GetStatus"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return svc.GetStatus(ctx, req.(*GetStatusRequest))
+	}
+	return interceptor(ctx, req, info, handler)
+}
+
+// decideStreamWrapper adapts a grpc.ServerStream to decideStreamServer.
+type decideStreamWrapper struct {
+	grpc.ServerStream
+}
+
+func (w *decideStreamWrapper) Send(resp *DecideResponse) error {
+	return w.ServerStream.SendMsg(resp)
+}
+
+func (w *decideStreamWrapper) Recv() (*DecideRequest, error) {
+	req := new(DecideRequest)
+	if err := w.ServerStream.RecvMsg(req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func decideStreamHandler(srv interface{}, stream grpc.ServerStream) error {
+	svc := srv.(*Service)
+	return svc.DecideStream(&decideStreamWrapper{ServerStream: stream})
+}
@@ -0,0 +1,47 @@
+package grpcserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ad-budget-pacer/pacer-service/pacer"
+)
+
+func TestStreamCache_GetMissesUntilPut(t *testing.T) {
+	c := &streamCache{ttl: time.Minute, entries: make(map[string]*cachedStatus)}
+
+	status, err := c.get("camp-1")
+	if err != nil || status != nil {
+		t.Fatalf("expected cache miss, got status=%v err=%v", status, err)
+	}
+
+	c.put("camp-1", &pacer.BudgetStatus{CampaignID: "camp-1"})
+	status, err = c.get("camp-1")
+	if err != nil || status == nil || status.CampaignID != "camp-1" {
+		t.Fatalf("expected cached status for camp-1, got status=%v err=%v", status, err)
+	}
+}
+
+func TestStreamCache_ExpiresAfterTTL(t *testing.T) {
+	c := &streamCache{ttl: time.Millisecond, entries: make(map[string]*cachedStatus)}
+
+	c.put("camp-1", &pacer.BudgetStatus{CampaignID: "camp-1"})
+	time.Sleep(5 * time.Millisecond)
+
+	status, err := c.get("camp-1")
+	if err != nil || status != nil {
+		t.Fatalf("expected expired entry to miss, got status=%v err=%v", status, err)
+	}
+}
+
+func TestStreamCache_InvalidateRemovesEntry(t *testing.T) {
+	c := &streamCache{ttl: time.Minute, entries: make(map[string]*cachedStatus)}
+
+	c.put("camp-1", &pacer.BudgetStatus{CampaignID: "camp-1"})
+	c.invalidate("camp-1")
+
+	status, _ := c.get("camp-1")
+	if status != nil {
+		t.Fatalf("expected invalidated entry to miss, got %v", status)
+	}
+}
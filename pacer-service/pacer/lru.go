@@ -0,0 +1,66 @@
+package pacer
+
+import (
+	"container/list"
+	"sync"
+)
+
+// lruDecisionCache is a small fixed-capacity LRU used to recall the exact
+// outcome of a prior keyed decision (see BidDedup). It intentionally only
+// supports the get/put pair BidDedup needs, and is safe for concurrent use.
+type lruDecisionCache struct {
+	mu       sync.Mutex
+	capacity int
+	ll       *list.List
+	items    map[string]*list.Element
+}
+
+type lruEntry struct {
+	key   string
+	value CachedDecision
+}
+
+func newLRUDecisionCache(capacity int) *lruDecisionCache {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	return &lruDecisionCache{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element, capacity),
+	}
+}
+
+func (c *lruDecisionCache) get(key string) (CachedDecision, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		return CachedDecision{}, false
+	}
+	c.ll.MoveToFront(elem)
+	return elem.Value.(*lruEntry).value, true
+}
+
+func (c *lruDecisionCache) put(key string, value CachedDecision) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		elem.Value.(*lruEntry).value = value
+		c.ll.MoveToFront(elem)
+		return
+	}
+
+	elem := c.ll.PushFront(&lruEntry{key: key, value: value})
+	c.items[key] = elem
+
+	if c.ll.Len() > c.capacity {
+		oldest := c.ll.Back()
+		if oldest != nil {
+			c.ll.Remove(oldest)
+			delete(c.items, oldest.Value.(*lruEntry).key)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package pacer
+
+import (
+	"sync"
+	"time"
+)
+
+// MemoryBudget stores in-memory budget tracking for one campaign. Its own
+// mu guards DailySpent/HourlySpent/CurrentHour/LastUpdate so concurrent
+// TrackSpend/GetBudgetStatus calls for the same campaign -- and recovery
+// snapshotting -- never read or write those fields half-updated; callers
+// that only need to find-or-create the *MemoryBudget pointer itself use
+// ResilientBudgetTracker.mu instead, which guards the memoryCache map.
+type MemoryBudget struct {
+	mu sync.Mutex
+
+	DailySpent  int64
+	HourlySpent int64
+	LastUpdate  time.Time
+	CurrentHour int
+}
+
+// resetHourlyIfChanged zeroes budget's hourly counter when now has rolled
+// into a new hour. Callers must hold budget.mu.
+func resetHourlyIfChanged(budget *MemoryBudget, now time.Time) {
+	if budget.CurrentHour != now.Hour() {
+		budget.HourlySpent = 0
+		budget.CurrentHour = now.Hour()
+	}
+}
+
+// snapshotMemoryBudget copies budget's counters into a fresh, unshared
+// MemoryBudget under budget's own lock. Recovery code queues the copy
+// rather than budget itself, so syncMemoryToStore never races a concurrent
+// TrackSpend mutating the live cache entry.
+func snapshotMemoryBudget(budget *MemoryBudget) *MemoryBudget {
+	budget.mu.Lock()
+	defer budget.mu.Unlock()
+	return &MemoryBudget{
+		DailySpent:  budget.DailySpent,
+		HourlySpent: budget.HourlySpent,
+		LastUpdate:  budget.LastUpdate,
+		CurrentHour: budget.CurrentHour,
+	}
+}
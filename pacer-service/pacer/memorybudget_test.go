@@ -0,0 +1,121 @@
+package pacer
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestUpdateMemoryCache_RecoverySnapshotIsIndependentCopy(t *testing.T) {
+	bt := &ResilientBudgetTracker{
+		memoryCache:   make(map[string]*MemoryBudget),
+		recoveryQueue: make(map[string]*MemoryBudget),
+		degradedMode:  true,
+	}
+
+	now := time.Now()
+	bt.updateMemoryCache("camp-1", 500, now)
+
+	live, ok := bt.memoryBudgetFor("camp-1")
+	if !ok {
+		t.Fatalf("expected a live cache entry")
+	}
+
+	bt.recoveryMu.Lock()
+	queued := bt.recoveryQueue["camp-1"]
+	bt.recoveryMu.Unlock()
+
+	if queued == live {
+		t.Fatalf("expected the recovery queue to hold a snapshot, not the live cache pointer")
+	}
+	if queued.DailySpent != 500 {
+		t.Fatalf("expected queued snapshot DailySpent 500, got %d", queued.DailySpent)
+	}
+
+	// A later update to the live entry must not retroactively change the
+	// snapshot already queued for recovery.
+	bt.updateMemoryCache("camp-1", 100, now)
+	if queued.DailySpent != 500 {
+		t.Fatalf("expected queued snapshot to stay frozen at 500, got %d", queued.DailySpent)
+	}
+}
+
+func TestGetFromMemory_ResetsStaleHourlySpentInPlace(t *testing.T) {
+	bt := &ResilientBudgetTracker{
+		memoryCache:   make(map[string]*MemoryBudget),
+		recoveryQueue: make(map[string]*MemoryBudget),
+	}
+
+	hourNine := time.Date(2026, 7, 25, 9, 30, 0, 0, time.UTC)
+	hourTen := hourNine.Add(time.Hour)
+
+	bt.updateMemoryCache("camp-1", 200, hourNine)
+
+	// Read after the hour rolled over: the view must already show the
+	// bucket reset, and the reset must be visible to the next writer too.
+	status := bt.getFromMemory("camp-1", 100000, hourTen)
+	if status.HourlySpent != 0 {
+		t.Fatalf("expected HourlySpent to reset across the hour boundary, got %d", status.HourlySpent)
+	}
+
+	bt.updateMemoryCache("camp-1", 50, hourTen)
+	budget, _ := bt.memoryBudgetFor("camp-1")
+	if budget.HourlySpent != 50 {
+		t.Fatalf("expected HourlySpent 50 for the new hour (not stacked on the stale value), got %d", budget.HourlySpent)
+	}
+	if budget.DailySpent != 250 {
+		t.Fatalf("expected DailySpent to keep accumulating across hours, got %d", budget.DailySpent)
+	}
+}
+
+func TestMemoryCache_ConcurrentTrackSpendAndGetBudgetStatusAcrossHourRollover(t *testing.T) {
+	bt := &ResilientBudgetTracker{
+		memoryCache:   make(map[string]*MemoryBudget),
+		recoveryQueue: make(map[string]*MemoryBudget),
+		storeBreaker:  tippedOpenStoreBreaker(), // force every GetBudgetStatus onto the memory path
+	}
+
+	before := time.Date(2026, 7, 25, 13, 59, 59, 0, time.UTC)
+	after := before.Add(2 * time.Second) // 14:00:01, rolls the hour
+
+	const writers = 20
+	const readers = 20
+	const perGoroutine = 200
+
+	var wg sync.WaitGroup
+	wg.Add(writers + readers)
+
+	for i := 0; i < writers; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perGoroutine; j++ {
+				now := before
+				if j%2 == 0 {
+					now = after
+				}
+				bt.updateMemoryCache("camp-1", 1, now)
+			}
+		}()
+	}
+	for i := 0; i < readers; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perGoroutine; j++ {
+				now := before
+				if j%2 == 0 {
+					now = after
+				}
+				_ = bt.getFromMemory("camp-1", 1000000, now)
+			}
+		}()
+	}
+	wg.Wait()
+
+	budget, ok := bt.memoryBudgetFor("camp-1")
+	if !ok {
+		t.Fatalf("expected camp-1 to have a cache entry")
+	}
+	if budget.DailySpent != int64(writers*perGoroutine) {
+		t.Fatalf("expected DailySpent %d (one increment per write, none lost to races), got %d", writers*perGoroutine, budget.DailySpent)
+	}
+}
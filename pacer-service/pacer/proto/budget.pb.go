@@ -0,0 +1,211 @@
+// Package proto holds the Go types for proto/budget.proto. Without a
+// protoc + protoc-gen-go toolchain available in this build environment,
+// BudgetRecord and HourlyBucket are hand-written here instead of generated,
+// but Marshal/Unmarshal implement the real protobuf wire format (varint
+// field tags, length-delimited strings/submessages) so on-disk records
+// stay forward-compatible with a real protoc-gen-go swap-in later -- this
+// should be treated as scaffolding to replace, not a permanent pattern.
+package proto
+
+import (
+	"errors"
+	"fmt"
+)
+
+// BudgetRecord is one campaign-day's structured spend record. See
+// proto/budget.proto for field semantics.
+type BudgetRecord struct {
+	CampaignId      string
+	Date            string
+	DailySpentCents int64
+	Hourly          []HourlyBucket
+	LastUpdateUnix  int64
+	Version         uint64
+}
+
+// HourlyBucket is one hour-of-day's accumulated spend within a BudgetRecord.
+type HourlyBucket struct {
+	Hour       int32
+	SpentCents int64
+}
+
+const (
+	wireVarint = 0
+	wireBytes  = 2
+)
+
+func appendVarint(buf []byte, v uint64) []byte {
+	for v >= 0x80 {
+		buf = append(buf, byte(v)|0x80)
+		v >>= 7
+	}
+	return append(buf, byte(v))
+}
+
+func appendTag(buf []byte, field, wireType int) []byte {
+	return appendVarint(buf, uint64(field)<<3|uint64(wireType))
+}
+
+// appendStringField omits the field entirely when s is empty, matching
+// proto3's "don't encode the zero value" convention for singular fields.
+func appendStringField(buf []byte, field int, s string) []byte {
+	if s == "" {
+		return buf
+	}
+	buf = appendTag(buf, field, wireBytes)
+	buf = appendVarint(buf, uint64(len(s)))
+	return append(buf, s...)
+}
+
+func appendVarintField(buf []byte, field int, v uint64) []byte {
+	if v == 0 {
+		return buf
+	}
+	buf = appendTag(buf, field, wireVarint)
+	return appendVarint(buf, v)
+}
+
+// appendMessageField always emits, even for an empty-looking submessage --
+// unlike singular scalars, repeated entries aren't collapsed in proto3.
+func appendMessageField(buf []byte, field int, msg []byte) []byte {
+	buf = appendTag(buf, field, wireBytes)
+	buf = appendVarint(buf, uint64(len(msg)))
+	return append(buf, msg...)
+}
+
+func readVarint(b []byte) (uint64, int, error) {
+	var v uint64
+	var shift uint
+	for i, c := range b {
+		v |= uint64(c&0x7f) << shift
+		if c < 0x80 {
+			return v, i + 1, nil
+		}
+		shift += 7
+		if shift >= 64 {
+			return 0, 0, errors.New("proto: varint overflows uint64")
+		}
+	}
+	return 0, 0, errors.New("proto: truncated varint")
+}
+
+// Marshal encodes r using the protobuf wire format described in
+// proto/budget.proto.
+func (r *BudgetRecord) Marshal() ([]byte, error) {
+	var buf []byte
+	buf = appendStringField(buf, 1, r.CampaignId)
+	buf = appendStringField(buf, 2, r.Date)
+	buf = appendVarintField(buf, 3, uint64(r.DailySpentCents))
+	for _, h := range r.Hourly {
+		hb, err := h.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		buf = appendMessageField(buf, 4, hb)
+	}
+	buf = appendVarintField(buf, 5, uint64(r.LastUpdateUnix))
+	buf = appendVarintField(buf, 6, r.Version)
+	return buf, nil
+}
+
+// Unmarshal decodes data produced by Marshal into r, resetting any
+// existing contents first.
+func (r *BudgetRecord) Unmarshal(data []byte) error {
+	*r = BudgetRecord{}
+
+	i := 0
+	for i < len(data) {
+		tag, n, err := readVarint(data[i:])
+		if err != nil {
+			return err
+		}
+		i += n
+		field := int(tag >> 3)
+		wireType := int(tag & 0x7)
+
+		switch wireType {
+		case wireVarint:
+			v, n, err := readVarint(data[i:])
+			if err != nil {
+				return err
+			}
+			i += n
+			switch field {
+			case 3:
+				r.DailySpentCents = int64(v)
+			case 5:
+				r.LastUpdateUnix = int64(v)
+			case 6:
+				r.Version = v
+			}
+		case wireBytes:
+			l, n, err := readVarint(data[i:])
+			if err != nil {
+				return err
+			}
+			i += n
+			if i+int(l) > len(data) {
+				return errors.New("proto: length-delimited field overruns message")
+			}
+			chunk := data[i : i+int(l)]
+			i += int(l)
+			switch field {
+			case 1:
+				r.CampaignId = string(chunk)
+			case 2:
+				r.Date = string(chunk)
+			case 4:
+				var hb HourlyBucket
+				if err := hb.Unmarshal(chunk); err != nil {
+					return err
+				}
+				r.Hourly = append(r.Hourly, hb)
+			}
+		default:
+			return fmt.Errorf("proto: unsupported wire type %d", wireType)
+		}
+	}
+	return nil
+}
+
+// Marshal encodes h using the protobuf wire format described in
+// proto/budget.proto.
+func (h *HourlyBucket) Marshal() ([]byte, error) {
+	var buf []byte
+	buf = appendVarintField(buf, 1, uint64(h.Hour))
+	buf = appendVarintField(buf, 2, uint64(h.SpentCents))
+	return buf, nil
+}
+
+// Unmarshal decodes data produced by Marshal into h, resetting any
+// existing contents first.
+func (h *HourlyBucket) Unmarshal(data []byte) error {
+	*h = HourlyBucket{}
+
+	i := 0
+	for i < len(data) {
+		tag, n, err := readVarint(data[i:])
+		if err != nil {
+			return err
+		}
+		i += n
+		field := int(tag >> 3)
+		wireType := int(tag & 0x7)
+
+		if wireType != wireVarint {
+			return fmt.Errorf("proto: unsupported wire type %d in HourlyBucket", wireType)
+		}
+		v, n, err := readVarint(data[i:])
+		if err != nil {
+			return err
+		}
+		i += n
+		switch field {
+		case 1:
+			h.Hour = int32(v)
+		case 2:
+			h.SpentCents = int64(v)
+		}
+	}
+	return nil
+}
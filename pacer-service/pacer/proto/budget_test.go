@@ -0,0 +1,56 @@
+package proto
+
+import "testing"
+
+func TestBudgetRecord_MarshalUnmarshalRoundTrip(t *testing.T) {
+	want := &BudgetRecord{
+		CampaignId:      "camp-1",
+		Date:            "2026-07-25",
+		DailySpentCents: 150000,
+		Hourly: []HourlyBucket{
+			{Hour: 9, SpentCents: 10000},
+			{Hour: 10, SpentCents: 20000},
+		},
+		LastUpdateUnix: 1785000000,
+		Version:        7,
+	}
+
+	data, err := want.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	got := &BudgetRecord{}
+	if err := got.Unmarshal(data); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	if got.CampaignId != want.CampaignId || got.Date != want.Date ||
+		got.DailySpentCents != want.DailySpentCents || got.LastUpdateUnix != want.LastUpdateUnix ||
+		got.Version != want.Version || len(got.Hourly) != len(want.Hourly) {
+		t.Fatalf("round trip mismatch: got %+v, want %+v", got, want)
+	}
+	for i := range want.Hourly {
+		if got.Hourly[i] != want.Hourly[i] {
+			t.Fatalf("hourly bucket %d mismatch: got %+v, want %+v", i, got.Hourly[i], want.Hourly[i])
+		}
+	}
+}
+
+func TestBudgetRecord_MarshalOmitsZeroValueScalars(t *testing.T) {
+	rec := &BudgetRecord{}
+	data, err := rec.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+	if len(data) != 0 {
+		t.Fatalf("expected an all-zero-value record to marshal to zero bytes, got %d bytes", len(data))
+	}
+}
+
+func TestBudgetRecord_UnmarshalRejectsTruncatedData(t *testing.T) {
+	rec := &BudgetRecord{}
+	if err := rec.Unmarshal([]byte{0x08}); err == nil {
+		t.Fatalf("expected a truncated varint field to return an error")
+	}
+}
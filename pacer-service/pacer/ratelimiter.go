@@ -0,0 +1,295 @@
+package pacer
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimiterStats summarizes a RateLimiter's current state for
+// diagnostics and metrics.
+type RateLimiterStats struct {
+	Strategy  string
+	Available float64
+	Limit     float64
+}
+
+// RateLimiter bounds the rate of operations (e.g. bid requests) a campaign
+// is allowed to perform. Implementations are swappable at runtime via
+// BudgetTracker.SetRateLimiter so operators can pick the strategy that
+// fits a campaign's traffic shape without forking the tracker.
+type RateLimiter interface {
+	// Acquire reports whether n units may proceed right now.
+	Acquire(n int64) bool
+	// Update reconfigures the limiter in place (e.g. after a campaign's
+	// rate limit changes) without losing accumulated state.
+	Update(cfg RateLimiterConfig)
+	Stats() RateLimiterStats
+}
+
+// RateLimiterConfig holds the tunables shared across limiter strategies;
+// each implementation uses the subset that applies to it.
+type RateLimiterConfig struct {
+	MaxTokens  float64
+	RefillRate float64       // tokens per second
+	TargetQPS  float64       // used by StableLimiter
+	RampUp     time.Duration // used by RampUpLimiter
+}
+
+// CampaignRateLimiterConfig is the per-campaign rate limiting strategy and
+// tunables, loaded from campaign config (the campaigns table's
+// rate_limiter_config column) and broadcast to followers alongside the
+// rest of CampaignUpdate so every instance installs the same limiter via
+// BudgetTracker.SetRateLimiter.
+type CampaignRateLimiterConfig struct {
+	Strategy   string  `json:"strategy"` // token_bucket (default), leaky_bucket, stable, ramp_up
+	MaxTokens  float64 `json:"max_tokens,omitempty"`
+	RefillRate float64 `json:"refill_rate,omitempty"`
+	TargetQPS  float64 `json:"target_qps,omitempty"`
+	RampUpMs   int64   `json:"ramp_up_ms,omitempty"`
+}
+
+// Build constructs the RateLimiter this config describes.
+func (c CampaignRateLimiterConfig) Build() RateLimiter {
+	cfg := RateLimiterConfig{
+		MaxTokens:  c.MaxTokens,
+		RefillRate: c.RefillRate,
+		TargetQPS:  c.TargetQPS,
+		RampUp:     time.Duration(c.RampUpMs) * time.Millisecond,
+	}
+
+	switch c.Strategy {
+	case "leaky_bucket":
+		return NewLeakyBucketLimiter(cfg)
+	case "stable":
+		return NewStableLimiter(cfg)
+	case "ramp_up":
+		return NewRampUpLimiter(cfg)
+	default:
+		return NewTokenBucketLimiter(cfg)
+	}
+}
+
+// TokenBucketLimiter is a classic token bucket: it allows bursts up to
+// MaxTokens and refills at RefillRate tokens/sec. This is the tracker's
+// original rate limiting behavior, now behind the RateLimiter interface.
+type TokenBucketLimiter struct {
+	mu         sync.Mutex
+	tokens     float64
+	maxTokens  float64
+	refillRate float64
+	lastRefill time.Time
+}
+
+func NewTokenBucketLimiter(cfg RateLimiterConfig) *TokenBucketLimiter {
+	return &TokenBucketLimiter{
+		tokens:     cfg.MaxTokens,
+		maxTokens:  cfg.MaxTokens,
+		refillRate: cfg.RefillRate,
+		lastRefill: time.Now(),
+	}
+}
+
+func (l *TokenBucketLimiter) refill() {
+	now := time.Now()
+	elapsed := now.Sub(l.lastRefill).Seconds()
+	l.tokens += elapsed * l.refillRate
+	if l.tokens > l.maxTokens {
+		l.tokens = l.maxTokens
+	}
+	l.lastRefill = now
+}
+
+func (l *TokenBucketLimiter) Acquire(n int64) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill()
+	if l.tokens >= float64(n) {
+		l.tokens -= float64(n)
+		return true
+	}
+	return false
+}
+
+func (l *TokenBucketLimiter) Update(cfg RateLimiterConfig) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.maxTokens = cfg.MaxTokens
+	l.refillRate = cfg.RefillRate
+	if l.tokens > l.maxTokens {
+		l.tokens = l.maxTokens
+	}
+}
+
+func (l *TokenBucketLimiter) Stats() RateLimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return RateLimiterStats{Strategy: "token_bucket", Available: l.tokens, Limit: l.maxTokens}
+}
+
+// LeakyBucketLimiter smooths bursty traffic: requests fill a bucket of
+// MaxTokens capacity that leaks (drains) at RefillRate units/sec. Unlike
+// the token bucket, it does not allow saved-up capacity to burst out faster
+// than the leak rate once the bucket is full.
+type LeakyBucketLimiter struct {
+	mu        sync.Mutex
+	level     float64
+	capacity  float64
+	leakRate  float64
+	lastCheck time.Time
+}
+
+func NewLeakyBucketLimiter(cfg RateLimiterConfig) *LeakyBucketLimiter {
+	return &LeakyBucketLimiter{
+		capacity:  cfg.MaxTokens,
+		leakRate:  cfg.RefillRate,
+		lastCheck: time.Now(),
+	}
+}
+
+func (l *LeakyBucketLimiter) leak() {
+	now := time.Now()
+	elapsed := now.Sub(l.lastCheck).Seconds()
+	l.level -= elapsed * l.leakRate
+	if l.level < 0 {
+		l.level = 0
+	}
+	l.lastCheck = now
+}
+
+func (l *LeakyBucketLimiter) Acquire(n int64) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.leak()
+	if l.level+float64(n) <= l.capacity {
+		l.level += float64(n)
+		return true
+	}
+	return false
+}
+
+func (l *LeakyBucketLimiter) Update(cfg RateLimiterConfig) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.capacity = cfg.MaxTokens
+	l.leakRate = cfg.RefillRate
+}
+
+func (l *LeakyBucketLimiter) Stats() RateLimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return RateLimiterStats{Strategy: "leaky_bucket", Available: l.capacity - l.level, Limit: l.capacity}
+}
+
+// StableLimiter targets a fixed QPS by spacing acquisitions at least
+// 1/TargetQPS apart, rejecting anything that would arrive too soon.
+type StableLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	nextSlot time.Time
+}
+
+func NewStableLimiter(cfg RateLimiterConfig) *StableLimiter {
+	interval := time.Second
+	if cfg.TargetQPS > 0 {
+		interval = time.Duration(float64(time.Second) / cfg.TargetQPS)
+	}
+	return &StableLimiter{interval: interval, nextSlot: time.Now()}
+}
+
+func (l *StableLimiter) Acquire(n int64) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if now.Before(l.nextSlot) {
+		return false
+	}
+	l.nextSlot = now.Add(time.Duration(n) * l.interval)
+	return true
+}
+
+func (l *StableLimiter) Update(cfg RateLimiterConfig) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if cfg.TargetQPS > 0 {
+		l.interval = time.Duration(float64(time.Second) / cfg.TargetQPS)
+	}
+}
+
+func (l *StableLimiter) Stats() RateLimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	qps := 0.0
+	if l.interval > 0 {
+		qps = float64(time.Second) / float64(l.interval)
+	}
+	return RateLimiterStats{Strategy: "stable", Limit: qps}
+}
+
+// RampUpLimiter linearly grows the allowed rate from zero to TargetQPS over
+// RampUp, so a paused campaign that resumes doesn't stampede downstream
+// auction servers the instant it's reactivated.
+type RampUpLimiter struct {
+	mu        sync.Mutex
+	startedAt time.Time
+	rampUp    time.Duration
+	targetQPS float64
+	inner     *StableLimiter
+}
+
+func NewRampUpLimiter(cfg RateLimiterConfig) *RampUpLimiter {
+	return &RampUpLimiter{
+		startedAt: time.Now(),
+		rampUp:    cfg.RampUp,
+		targetQPS: cfg.TargetQPS,
+		inner:     NewStableLimiter(RateLimiterConfig{TargetQPS: cfg.TargetQPS}),
+	}
+}
+
+func (l *RampUpLimiter) currentQPS() float64 {
+	if l.rampUp <= 0 {
+		return l.targetQPS
+	}
+	elapsed := time.Since(l.startedAt)
+	if elapsed >= l.rampUp {
+		return l.targetQPS
+	}
+	progress := float64(elapsed) / float64(l.rampUp)
+	return l.targetQPS * progress
+}
+
+func (l *RampUpLimiter) Acquire(n int64) bool {
+	l.mu.Lock()
+	qps := l.currentQPS()
+	l.mu.Unlock()
+
+	if qps <= 0 {
+		return false
+	}
+
+	l.inner.Update(RateLimiterConfig{TargetQPS: qps})
+	return l.inner.Acquire(n)
+}
+
+func (l *RampUpLimiter) Update(cfg RateLimiterConfig) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.rampUp = cfg.RampUp
+	l.targetQPS = cfg.TargetQPS
+}
+
+func (l *RampUpLimiter) Stats() RateLimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return RateLimiterStats{Strategy: "ramp_up", Limit: l.currentQPS()}
+}
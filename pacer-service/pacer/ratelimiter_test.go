@@ -0,0 +1,40 @@
+package pacer
+
+import "testing"
+
+func TestTokenBucketLimiter_AllowsBurstThenBlocks(t *testing.T) {
+	rl := NewTokenBucketLimiter(RateLimiterConfig{MaxTokens: 10, RefillRate: 0})
+
+	if !rl.Acquire(10) {
+		t.Fatal("expected initial burst of 10 to be allowed")
+	}
+	if rl.Acquire(1) {
+		t.Error("expected acquire to fail once tokens are exhausted")
+	}
+}
+
+func TestLeakyBucketLimiter_RejectsOverCapacity(t *testing.T) {
+	rl := NewLeakyBucketLimiter(RateLimiterConfig{MaxTokens: 5, RefillRate: 0})
+
+	if !rl.Acquire(5) {
+		t.Fatal("expected fill to capacity to succeed")
+	}
+	if rl.Acquire(1) {
+		t.Error("expected acquire beyond capacity to be rejected")
+	}
+}
+
+func TestBudgetTracker_CheckRateLimit(t *testing.T) {
+	tracker := NewBudgetTracker("localhost:6379")
+	tracker.SetRateLimiter("camp-1", NewTokenBucketLimiter(RateLimiterConfig{MaxTokens: 5, RefillRate: 0}))
+
+	if !tracker.CheckRateLimit("camp-1", 5) {
+		t.Fatal("expected rate limit to allow within burst")
+	}
+	if tracker.CheckRateLimit("camp-1", 1) {
+		t.Error("expected rate limit to deny once exhausted")
+	}
+	if !tracker.CheckRateLimit("camp-unconfigured", 1000) {
+		t.Error("expected campaigns with no configured limiter to pass through")
+	}
+}
@@ -0,0 +1,101 @@
+package pacer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	log "github.com/sirupsen/logrus"
+)
+
+// trackSpendIfUnderScript performs the check-then-increment TrackSpend and
+// GetBudgetStatus otherwise leave racy across concurrent callers, all in one
+// round-trip: it reads the current day's spend, and only applies amount if
+// doing so wouldn't exceed dailyBudget. KEYS are [dayKey, hourKey]; ARGV are
+// [amount, dailyBudget, dayTTLSeconds, hourTTLSeconds]. Returns {1, newSpent}
+// on acceptance or {0, spent} when amount would overspend. redis.Script
+// handles the EVALSHA-with-EVAL-on-NOSCRIPT caching dance for us.
+var trackSpendIfUnderScript = redis.NewScript(`
+local spent = tonumber(redis.call('GET', KEYS[1]) or '0')
+local amount = tonumber(ARGV[1])
+local budget = tonumber(ARGV[2])
+if spent + amount > budget then
+	return {0, spent}
+end
+local newSpent = redis.call('INCRBY', KEYS[1], amount)
+redis.call('EXPIRE', KEYS[1], ARGV[3])
+redis.call('INCRBY', KEYS[2], amount)
+redis.call('EXPIRE', KEYS[2], ARGV[4])
+return {1, newSpent}
+`)
+
+// TrackSpendIfUnder atomically checks campaignID's daily spend against
+// dailyBudget and applies amount only if doing so wouldn't exceed it,
+// closing the race window two concurrent bidders would otherwise find
+// between a TrackSpend and a GetBudgetStatus call both observing "under
+// budget". accepted reports whether amount was applied; newSpent is the
+// resulting (or, if rejected, the unchanged) daily total. Falls back to a
+// mutex-guarded check-and-increment against the stale cache if the script
+// can't complete within the configured deadline.
+func (bt *BudgetTracker) TrackSpendIfUnder(ctx context.Context, campaignID string, amount int64, dailyBudget int64) (accepted bool, newSpent int64, err error) {
+	now := time.Now()
+	dayKey := bt.getDayKey(campaignID, now)
+	hourKey := bt.getHourKey(campaignID, now)
+
+	ctx, cancel := bt.withOpDeadline(ctx)
+	defer cancel()
+
+	done := observeRedisPipeline("track_spend_if_under")
+	res, err := trackSpendIfUnderScript.Run(ctx, bt.redisClient, []string{dayKey, hourKey},
+		amount, dailyBudget, int((25 * time.Hour).Seconds()), int((2 * time.Hour).Seconds())).Result()
+	done()
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return bt.trackSpendIfUnderDegraded(campaignID, amount, dailyBudget)
+		}
+		log.WithError(err).Error("Failed to run atomic spend-and-check script")
+		return false, 0, err
+	}
+
+	vals, ok := res.([]interface{})
+	if !ok || len(vals) != 2 {
+		return false, 0, fmt.Errorf("pacer: unexpected TrackSpendIfUnder script result %#v", res)
+	}
+	acceptedVal, ok1 := vals[0].(int64)
+	newSpentVal, ok2 := vals[1].(int64)
+	if !ok1 || !ok2 {
+		return false, 0, fmt.Errorf("pacer: unexpected TrackSpendIfUnder script result %#v", res)
+	}
+
+	bt.invalidateCache(campaignID)
+	return acceptedVal == 1, newSpentVal, nil
+}
+
+// trackSpendIfUnderDegraded performs the same check-and-increment against
+// bt's stale BudgetStatus cache when the Redis script couldn't complete in
+// time, mirroring GetBudgetStatus's deadline-driven stale-cache fallback.
+func (bt *BudgetTracker) trackSpendIfUnderDegraded(campaignID string, amount int64, dailyBudget int64) (bool, int64, error) {
+	bt.mu.Lock()
+	defer bt.mu.Unlock()
+
+	var spent int64
+	if status, exists := bt.cache[campaignID]; exists {
+		spent = status.DailySpent
+	}
+	if spent+amount > dailyBudget {
+		return false, spent, nil
+	}
+
+	newSpent := spent + amount
+	bt.cache[campaignID] = &BudgetStatus{
+		CampaignID:   campaignID,
+		DailyBudget:  dailyBudget,
+		DailySpent:   newSpent,
+		HourlyBudget: dailyBudget / 24,
+		DegradedMode: true,
+		Warning:      "redis budget guard exceeded decision deadline; accepted against stale cached spend",
+	}
+	return true, newSpent, nil
+}
@@ -0,0 +1,44 @@
+package pacer
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTrackSpendIfUnder_FallsBackToDegradedCheckAndIncrementOnDeadline(t *testing.T) {
+	addr := hangingRedisListener(t)
+	bt := NewBudgetTracker(addr)
+	bt.SetDeadline(20 * time.Millisecond)
+
+	accepted, newSpent, err := bt.TrackSpendIfUnder(context.Background(), "camp-1", 400, 1000)
+	if err != nil {
+		t.Fatalf("expected degraded fallback, got error: %v", err)
+	}
+	if !accepted {
+		t.Fatalf("expected spend under budget to be accepted")
+	}
+	if newSpent != 400 {
+		t.Fatalf("expected newSpent 400, got %d", newSpent)
+	}
+
+	status, ok := bt.staleCache("camp-1")
+	if !ok {
+		t.Fatalf("expected degraded fallback to populate the cache")
+	}
+	if !status.DegradedMode {
+		t.Fatalf("expected degraded status to be marked DegradedMode")
+	}
+
+	// A second call that would push spend over budget must be rejected.
+	accepted, rejectedSpent, err := bt.TrackSpendIfUnder(context.Background(), "camp-1", 700, 1000)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if accepted {
+		t.Fatalf("expected overspend to be rejected")
+	}
+	if rejectedSpent != 400 {
+		t.Fatalf("expected unchanged spend of 400 to be reported, got %d", rejectedSpent)
+	}
+}
@@ -0,0 +1,272 @@
+package pacer
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// StoreCircuitState is the state of a storeCircuitBreaker.
+type StoreCircuitState string
+
+const (
+	StoreCircuitClosed   StoreCircuitState = "CLOSED"
+	StoreCircuitOpen     StoreCircuitState = "OPEN"
+	StoreCircuitHalfOpen StoreCircuitState = "HALF_OPEN"
+)
+
+func (s StoreCircuitState) gaugeValue() float64 {
+	switch s {
+	case StoreCircuitOpen:
+		return 2
+	case StoreCircuitHalfOpen:
+		return 1
+	default:
+		return 0
+	}
+}
+
+var storeCircuitBreakerState = prometheus.NewGauge(prometheus.GaugeOpts{
+	Name: "pacer_store_circuit_breaker_state",
+	Help: "Current state of the budget store circuit breaker (0=closed, 1=half-open, 2=open)",
+})
+
+func init() {
+	prometheus.MustRegister(storeCircuitBreakerState)
+}
+
+// storeCircuitBreaker guards access to a ResilientBudgetTracker's backing
+// BudgetStore, replacing a plain healthy/unhealthy flag with CLOSED /
+// OPEN / HALF_OPEN states, a sliding-window failure threshold, and
+// exponential backoff between reconnect attempts. Its zero value is a
+// usable, closed breaker with the defaults below, so tests can embed one in
+// a struct literal without going through a constructor.
+//
+// AllowRequest is the single gate every store call site should check before
+// attempting an operation, and must be paired with a RecordSuccess or
+// RecordFailure once that operation completes -- this is what allows a
+// HALF_OPEN breaker to admit exactly one trial request at a time instead of
+// every caller racing to reconnect at once.
+type storeCircuitBreaker struct {
+	mu sync.Mutex
+
+	state    StoreCircuitState
+	failures []time.Time
+
+	window           time.Duration
+	failureThreshold int
+	successThreshold int
+	baseBackoff      time.Duration
+	maxBackoff       time.Duration
+
+	consecutiveTrips  int
+	openedAt          time.Time
+	probeInFlight     bool
+	halfOpenSuccesses int
+
+	onStateChange []func(from, to StoreCircuitState)
+}
+
+const (
+	defaultFailureWindow    = 30 * time.Second
+	defaultFailureThreshold = 5
+	defaultSuccessThreshold = 2
+	defaultBaseBackoff      = 1 * time.Second
+	defaultMaxBackoff       = 2 * time.Minute
+)
+
+// effectiveState returns cb.state, treating the zero value as CLOSED.
+// Callers must hold cb.mu.
+func (cb *storeCircuitBreaker) effectiveState() StoreCircuitState {
+	if cb.state == "" {
+		return StoreCircuitClosed
+	}
+	return cb.state
+}
+
+// ensureDefaultsLocked fills in zero-valued tunables the first time the
+// breaker is used, so a bare storeCircuitBreaker{} behaves like one built
+// with sensible production defaults. Callers must hold cb.mu.
+func (cb *storeCircuitBreaker) ensureDefaultsLocked() {
+	if cb.window <= 0 {
+		cb.window = defaultFailureWindow
+	}
+	if cb.failureThreshold <= 0 {
+		cb.failureThreshold = defaultFailureThreshold
+	}
+	if cb.successThreshold <= 0 {
+		cb.successThreshold = defaultSuccessThreshold
+	}
+	if cb.baseBackoff <= 0 {
+		cb.baseBackoff = defaultBaseBackoff
+	}
+	if cb.maxBackoff <= 0 {
+		cb.maxBackoff = defaultMaxBackoff
+	}
+}
+
+// currentBackoff returns how long an OPEN breaker waits before its next
+// probe, doubling with each consecutive trip up to maxBackoff. Callers must
+// hold cb.mu.
+func (cb *storeCircuitBreaker) currentBackoff() time.Duration {
+	if cb.consecutiveTrips <= 1 {
+		return cb.baseBackoff
+	}
+	shift := uint(cb.consecutiveTrips - 1)
+	if shift >= 32 {
+		return cb.maxBackoff
+	}
+	backoff := cb.baseBackoff << shift
+	if backoff <= 0 || backoff > cb.maxBackoff {
+		return cb.maxBackoff
+	}
+	return backoff
+}
+
+// AllowRequest reports whether the caller may attempt an operation against
+// the store right now. In HALF_OPEN it hands out exactly one in-flight
+// probe at a time; the caller must report the outcome via RecordSuccess or
+// RecordFailure.
+func (cb *storeCircuitBreaker) AllowRequest() bool {
+	cb.mu.Lock()
+	cb.ensureDefaultsLocked()
+
+	var from, to StoreCircuitState
+	transitioned := false
+	allow := false
+
+	switch cb.effectiveState() {
+	case StoreCircuitClosed:
+		allow = true
+
+	case StoreCircuitOpen:
+		if time.Since(cb.openedAt) >= cb.currentBackoff() {
+			from, to = cb.effectiveState(), StoreCircuitHalfOpen
+			cb.state = StoreCircuitHalfOpen
+			cb.halfOpenSuccesses = 0
+			cb.probeInFlight = true
+			transitioned = true
+			allow = true
+		}
+
+	case StoreCircuitHalfOpen:
+		if !cb.probeInFlight {
+			cb.probeInFlight = true
+			allow = true
+		}
+	}
+	cb.mu.Unlock()
+
+	if transitioned {
+		cb.notify(from, to)
+	}
+	return allow
+}
+
+// RecordSuccess reports that an operation admitted by AllowRequest
+// succeeded.
+func (cb *storeCircuitBreaker) RecordSuccess() {
+	cb.mu.Lock()
+	cb.ensureDefaultsLocked()
+
+	var from, to StoreCircuitState
+	transitioned := false
+
+	switch cb.effectiveState() {
+	case StoreCircuitHalfOpen:
+		cb.probeInFlight = false
+		cb.halfOpenSuccesses++
+		if cb.halfOpenSuccesses >= cb.successThreshold {
+			from, to = cb.effectiveState(), StoreCircuitClosed
+			cb.state = StoreCircuitClosed
+			cb.consecutiveTrips = 0
+			cb.failures = nil
+			transitioned = true
+		}
+	case StoreCircuitClosed:
+		cb.failures = nil
+	}
+	cb.mu.Unlock()
+
+	if transitioned {
+		cb.notify(from, to)
+	}
+}
+
+// RecordFailure reports that an operation admitted by AllowRequest failed.
+// A failed HALF_OPEN probe reopens the breaker immediately with a longer
+// backoff; in CLOSED it accumulates into the sliding window and trips once
+// failureThreshold failures land within window.
+func (cb *storeCircuitBreaker) RecordFailure() {
+	cb.mu.Lock()
+	cb.ensureDefaultsLocked()
+	now := time.Now()
+
+	var from, to StoreCircuitState
+	transitioned := false
+
+	if cb.effectiveState() == StoreCircuitHalfOpen {
+		cb.probeInFlight = false
+		from, to = cb.effectiveState(), StoreCircuitOpen
+		cb.consecutiveTrips++
+		cb.openedAt = now
+		cb.failures = nil
+		cb.state = StoreCircuitOpen
+		transitioned = true
+	} else if cb.effectiveState() == StoreCircuitClosed {
+		cutoff := now.Add(-cb.window)
+		kept := cb.failures[:0]
+		for _, f := range cb.failures {
+			if f.After(cutoff) {
+				kept = append(kept, f)
+			}
+		}
+		cb.failures = append(kept, now)
+
+		if len(cb.failures) >= cb.failureThreshold {
+			from, to = cb.effectiveState(), StoreCircuitOpen
+			cb.consecutiveTrips++
+			cb.openedAt = now
+			cb.failures = nil
+			cb.state = StoreCircuitOpen
+			transitioned = true
+		}
+	}
+	cb.mu.Unlock()
+
+	if transitioned {
+		cb.notify(from, to)
+	}
+}
+
+// State returns the breaker's current state without claiming a probe slot.
+// Use this for passive inspection (health reporting, gating background
+// loops); use AllowRequest when about to attempt a store operation.
+func (cb *storeCircuitBreaker) State() StoreCircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.effectiveState()
+}
+
+// OnStateChange registers fn to be called, synchronously on the goroutine
+// that observed the transition, every time the breaker changes state.
+func (cb *storeCircuitBreaker) OnStateChange(fn func(from, to StoreCircuitState)) {
+	cb.mu.Lock()
+	cb.onStateChange = append(cb.onStateChange, fn)
+	cb.mu.Unlock()
+}
+
+// notify fires registered OnStateChange hooks and updates the Prometheus
+// gauge. Must be called without cb.mu held.
+func (cb *storeCircuitBreaker) notify(from, to StoreCircuitState) {
+	storeCircuitBreakerState.Set(to.gaugeValue())
+
+	cb.mu.Lock()
+	hooks := append([]func(from, to StoreCircuitState){}, cb.onStateChange...)
+	cb.mu.Unlock()
+
+	for _, hook := range hooks {
+		hook(from, to)
+	}
+}
@@ -0,0 +1,132 @@
+package pacer
+
+import (
+	"testing"
+	"time"
+)
+
+// tippedOpenStoreBreaker returns a storeCircuitBreaker already tripped OPEN
+// with a backoff long enough that AllowRequest won't flip it to HALF_OPEN
+// during a test run. Used by other tests in this package that want to force
+// a tracker onto its memory-cache fallback path.
+func tippedOpenStoreBreaker() storeCircuitBreaker {
+	return storeCircuitBreaker{
+		state:       StoreCircuitOpen,
+		openedAt:    time.Now(),
+		baseBackoff: time.Hour,
+	}
+}
+
+func TestStoreCircuitBreaker_ZeroValueIsClosedAndAllows(t *testing.T) {
+	var cb storeCircuitBreaker
+	if cb.State() != StoreCircuitClosed {
+		t.Fatalf("expected zero-value breaker to be CLOSED, got %s", cb.State())
+	}
+	if !cb.AllowRequest() {
+		t.Fatal("expected CLOSED breaker to allow requests")
+	}
+}
+
+func TestStoreCircuitBreaker_TripsOpenAfterThresholdFailures(t *testing.T) {
+	cb := storeCircuitBreaker{failureThreshold: 3, window: time.Minute}
+
+	for i := 0; i < 2; i++ {
+		cb.AllowRequest()
+		cb.RecordFailure()
+	}
+	if cb.State() != StoreCircuitClosed {
+		t.Fatalf("expected breaker to stay CLOSED below threshold, got %s", cb.State())
+	}
+
+	cb.AllowRequest()
+	cb.RecordFailure()
+	if cb.State() != StoreCircuitOpen {
+		t.Fatalf("expected breaker to trip OPEN at threshold, got %s", cb.State())
+	}
+	if cb.AllowRequest() {
+		t.Fatal("expected an OPEN breaker within its backoff window to deny requests")
+	}
+}
+
+func TestStoreCircuitBreaker_OldFailuresAgeOutOfTheWindow(t *testing.T) {
+	cb := storeCircuitBreaker{failureThreshold: 2, window: 10 * time.Millisecond}
+
+	cb.AllowRequest()
+	cb.RecordFailure()
+	time.Sleep(20 * time.Millisecond)
+
+	cb.AllowRequest()
+	cb.RecordFailure()
+	if cb.State() != StoreCircuitClosed {
+		t.Fatalf("expected the first failure to have aged out of the window, got %s", cb.State())
+	}
+}
+
+func TestStoreCircuitBreaker_HalfOpenAdmitsOneProbeAndCloses(t *testing.T) {
+	cb := storeCircuitBreaker{
+		failureThreshold: 1,
+		successThreshold: 1,
+		baseBackoff:      10 * time.Millisecond,
+		window:           time.Minute,
+	}
+
+	cb.AllowRequest()
+	cb.RecordFailure()
+	if cb.State() != StoreCircuitOpen {
+		t.Fatalf("expected breaker to trip OPEN, got %s", cb.State())
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if !cb.AllowRequest() {
+		t.Fatal("expected the breaker to admit a HALF_OPEN probe after the backoff elapses")
+	}
+	if cb.AllowRequest() {
+		t.Fatal("expected a second concurrent caller to be denied while a probe is in flight")
+	}
+
+	cb.RecordSuccess()
+	if cb.State() != StoreCircuitClosed {
+		t.Fatalf("expected a successful probe to close the breaker, got %s", cb.State())
+	}
+}
+
+func TestStoreCircuitBreaker_FailedProbeReopensWithLongerBackoff(t *testing.T) {
+	cb := storeCircuitBreaker{
+		failureThreshold: 1,
+		baseBackoff:      10 * time.Millisecond,
+		window:           time.Minute,
+	}
+
+	cb.AllowRequest()
+	cb.RecordFailure() // trip #1, backoff = baseBackoff
+	firstBackoff := cb.currentBackoff()
+
+	time.Sleep(firstBackoff + 5*time.Millisecond)
+	cb.AllowRequest() // admits the HALF_OPEN probe
+	cb.RecordFailure()
+	if cb.State() != StoreCircuitOpen {
+		t.Fatalf("expected a failed probe to reopen the breaker, got %s", cb.State())
+	}
+
+	secondBackoff := cb.currentBackoff()
+	if secondBackoff <= firstBackoff {
+		t.Fatalf("expected backoff to grow after a second trip: first=%s second=%s", firstBackoff, secondBackoff)
+	}
+}
+
+func TestStoreCircuitBreaker_OnStateChangeFiresOnTransitions(t *testing.T) {
+	cb := storeCircuitBreaker{failureThreshold: 1, window: time.Minute}
+
+	var got []string
+	cb.OnStateChange(func(from, to StoreCircuitState) {
+		got = append(got, string(from)+"->"+string(to))
+	})
+
+	cb.AllowRequest()
+	cb.RecordFailure()
+
+	if len(got) != 1 || got[0] != "CLOSED->OPEN" {
+		t.Fatalf("expected exactly one CLOSED->OPEN transition, got %v", got)
+	}
+}
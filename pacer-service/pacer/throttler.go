@@ -0,0 +1,162 @@
+package pacer
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	minInflight       = 16
+	maxInflightCap    = 256
+	halfOpenMaxWindow = 4
+	throttleCooldown  = 100 * time.Millisecond
+	aimdIncrement     = 2
+)
+
+// throttleState holds the AIMD window for a single campaign.
+type throttleState struct {
+	window      int64 // atomic: current allowed inflight window
+	inflight    int64 // atomic: current inflight count
+	rejected    int64 // atomic: total rejections (for metrics)
+	lastDecease int64 // atomic: unix-nanos of last multiplicative decrease
+}
+
+// AdaptiveThrottler bounds concurrent bid decisions per campaign using an
+// AIMD (additive-increase/multiplicative-decrease) feedback loop, similar to
+// TCP congestion control: the allowed inflight window grows slowly on
+// success and collapses quickly on throttling signals from downstream.
+type AdaptiveThrottler struct {
+	mu     sync.RWMutex
+	states map[string]*throttleState
+
+	circuitBreaker *CircuitBreakerManager
+}
+
+// NewAdaptiveThrottler creates a throttler that integrates with cbm to clamp
+// the window while a campaign's breaker is probing in HALF_OPEN.
+func NewAdaptiveThrottler(cbm *CircuitBreakerManager) *AdaptiveThrottler {
+	return &AdaptiveThrottler{
+		states:         make(map[string]*throttleState),
+		circuitBreaker: cbm,
+	}
+}
+
+func (at *AdaptiveThrottler) getState(campaignID string) *throttleState {
+	at.mu.RLock()
+	state, exists := at.states[campaignID]
+	at.mu.RUnlock()
+
+	if exists {
+		return state
+	}
+
+	at.mu.Lock()
+	defer at.mu.Unlock()
+
+	if state, exists = at.states[campaignID]; exists {
+		return state
+	}
+
+	state = &throttleState{window: minInflight}
+	at.states[campaignID] = state
+	return state
+}
+
+// maxInflight returns the current ceiling for campaignID, clamped low while
+// the circuit breaker is HALF_OPEN so recovery probes stay careful.
+func (at *AdaptiveThrottler) maxInflight(campaignID string) int64 {
+	if at.circuitBreaker != nil {
+		breaker := at.circuitBreaker.GetBreaker(campaignID)
+		if breaker.GetState() == HALF_OPEN {
+			return halfOpenMaxWindow
+		}
+	}
+	return maxInflightCap
+}
+
+// Acquire attempts to reserve one inflight slot for campaignID. It returns
+// ok=false (fast, no allocation) if the current window is already full. The
+// returned release func must be called exactly once when the bid decision
+// completes.
+func (at *AdaptiveThrottler) Acquire(campaignID string) (release func(), ok bool) {
+	state := at.getState(campaignID)
+
+	window := atomic.LoadInt64(&state.window)
+	if max := at.maxInflight(campaignID); window > max {
+		window = max
+	}
+
+	if atomic.AddInt64(&state.inflight, 1) > window {
+		atomic.AddInt64(&state.inflight, -1)
+		atomic.AddInt64(&state.rejected, 1)
+		return nil, false
+	}
+
+	released := int32(0)
+	return func() {
+		if atomic.CompareAndSwapInt32(&released, 0, 1) {
+			atomic.AddInt64(&state.inflight, -1)
+		}
+	}, true
+}
+
+// Success reports that an acquired bid decision completed successfully,
+// additively growing the window up to the current ceiling.
+func (at *AdaptiveThrottler) Success(campaignID string) {
+	state := at.getState(campaignID)
+	max := at.maxInflight(campaignID)
+
+	for {
+		current := atomic.LoadInt64(&state.window)
+		if current >= max {
+			return
+		}
+		next := current + aimdIncrement
+		if next > max {
+			next = max
+		}
+		if atomic.CompareAndSwapInt64(&state.window, current, next) {
+			return
+		}
+	}
+}
+
+// Throttled reports a downstream rejection (auction 429/5xx, circuit breaker
+// deny) and multiplicatively shrinks the window. Only one decrease is
+// applied per cool-down interval so a burst of failures doesn't collapse the
+// window to the floor in a single RTT.
+func (at *AdaptiveThrottler) Throttled(campaignID string) {
+	state := at.getState(campaignID)
+
+	now := time.Now().UnixNano()
+	last := atomic.LoadInt64(&state.lastDecease)
+	if time.Duration(now-last) < throttleCooldown {
+		return
+	}
+	if !atomic.CompareAndSwapInt64(&state.lastDecease, last, now) {
+		return
+	}
+
+	for {
+		current := atomic.LoadInt64(&state.window)
+		next := current / 2
+		if next < minInflight {
+			next = minInflight
+		}
+		if atomic.CompareAndSwapInt64(&state.window, current, next) {
+			return
+		}
+	}
+}
+
+// WindowSize returns the current AIMD window for campaignID (for metrics).
+func (at *AdaptiveThrottler) WindowSize(campaignID string) int64 {
+	return atomic.LoadInt64(&at.getState(campaignID).window)
+}
+
+// Rejected returns the total number of Acquire rejections for campaignID
+// (for metrics).
+func (at *AdaptiveThrottler) Rejected(campaignID string) int64 {
+	return atomic.LoadInt64(&at.getState(campaignID).rejected)
+}
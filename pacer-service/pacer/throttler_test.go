@@ -0,0 +1,61 @@
+package pacer
+
+import (
+	"testing"
+)
+
+func TestAdaptiveThrottler_AcquireRespectsWindow(t *testing.T) {
+	at := NewAdaptiveThrottler(nil)
+
+	var releases []func()
+	for i := 0; i < minInflight; i++ {
+		release, ok := at.Acquire("camp-1")
+		if !ok {
+			t.Fatalf("expected acquire %d to succeed within min window", i)
+		}
+		releases = append(releases, release)
+	}
+
+	if _, ok := at.Acquire("camp-1"); ok {
+		t.Error("expected acquire to fail once window is exhausted")
+	}
+
+	for _, release := range releases {
+		release()
+	}
+
+	if _, ok := at.Acquire("camp-1"); !ok {
+		t.Error("expected acquire to succeed after releasing inflight slots")
+	}
+}
+
+func TestAdaptiveThrottler_SuccessGrowsWindow(t *testing.T) {
+	at := NewAdaptiveThrottler(nil)
+
+	at.Success("camp-1")
+	if got := at.WindowSize("camp-1"); got != minInflight+aimdIncrement {
+		t.Errorf("expected window to grow additively to %d, got %d", minInflight+aimdIncrement, got)
+	}
+}
+
+func TestAdaptiveThrottler_ThrottledShrinksWindow(t *testing.T) {
+	at := NewAdaptiveThrottler(nil)
+
+	for i := 0; i < 10; i++ {
+		at.Success("camp-1")
+	}
+	before := at.WindowSize("camp-1")
+
+	at.Throttled("camp-1")
+	after := at.WindowSize("camp-1")
+
+	if after >= before {
+		t.Errorf("expected window to shrink after throttle signal, before=%d after=%d", before, after)
+	}
+
+	// A second throttle within the cool-down window should be a no-op.
+	at.Throttled("camp-1")
+	if got := at.WindowSize("camp-1"); got != after {
+		t.Errorf("expected cooldown to suppress repeated decrease, got %d want %d", got, after)
+	}
+}
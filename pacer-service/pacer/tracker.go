@@ -3,6 +3,7 @@ package pacer
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"sync"
@@ -13,10 +14,14 @@ import (
 )
 
 type BudgetTracker struct {
-	redisClient *redis.Client
-	mu          sync.RWMutex
-	cache       map[string]*BudgetStatus
-	cacheTTL    time.Duration
+	redisClient  *redis.Client
+	mu           sync.RWMutex
+	cache        map[string]*BudgetStatus
+	cacheTTL     time.Duration
+	rateLimiters map[string]RateLimiter
+	rateLimitMu  sync.RWMutex
+	batcher      *pipelineBatcher
+	opDeadline   time.Duration
 }
 
 func NewBudgetTracker(redisAddr string) *BudgetTracker {
@@ -29,9 +34,103 @@ func NewBudgetTracker(redisAddr string) *BudgetTracker {
 	})
 
 	return &BudgetTracker{
-		redisClient: rdb,
-		cache:       make(map[string]*BudgetStatus),
-		cacheTTL:    5 * time.Second,
+		redisClient:  rdb,
+		cache:        make(map[string]*BudgetStatus),
+		cacheTTL:     5 * time.Second,
+		rateLimiters: make(map[string]RateLimiter),
+	}
+}
+
+// SetRateLimiter installs rl as the rate limiting strategy for campaignID,
+// replacing any previously configured limiter. This lets operators switch
+// strategies at runtime (e.g. load a RampUpLimiter from campaign config
+// when a paused campaign resumes).
+func (bt *BudgetTracker) SetRateLimiter(campaignID string, rl RateLimiter) {
+	bt.rateLimitMu.Lock()
+	defer bt.rateLimitMu.Unlock()
+	bt.rateLimiters[campaignID] = rl
+}
+
+// SetDeadline configures the per-operation timeout that GetBudgetStatus
+// and TrackSpendIfUnder apply to their Redis calls when a caller's context
+// carries no deadline of its own
+// (e.g. background jobs that never threaded a request context through).
+// It does not override a deadline already present on ctx. Pass 0 to rely
+// solely on caller-supplied context deadlines.
+func (bt *BudgetTracker) SetDeadline(d time.Duration) {
+	bt.mu.Lock()
+	bt.opDeadline = d
+	bt.mu.Unlock()
+}
+
+// withOpDeadline returns ctx unchanged if it already has a deadline,
+// otherwise applies the configured SetDeadline timeout, if any.
+func (bt *BudgetTracker) withOpDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, hasDeadline := ctx.Deadline(); hasDeadline {
+		return ctx, func() {}
+	}
+
+	bt.mu.RLock()
+	d := bt.opDeadline
+	bt.mu.RUnlock()
+
+	if d <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, d)
+}
+
+// CheckRateLimit reports whether n units of work may proceed for
+// campaignID under its configured RateLimiter. Campaigns with no limiter
+// configured are never rate limited.
+func (bt *BudgetTracker) CheckRateLimit(campaignID string, n int64) bool {
+	bt.rateLimitMu.RLock()
+	rl, exists := bt.rateLimiters[campaignID]
+	bt.rateLimitMu.RUnlock()
+
+	if !exists {
+		return true
+	}
+	return rl.Acquire(n)
+}
+
+// EnablePipelinedWrites switches TrackSpend/BatchTrackSpend to buffer
+// increments in-process and flush them as a single Redis pipeline every
+// period, coalescing multiple increments to the same key into one IncrBy.
+// Call Flush or Close to drain the buffer (e.g. during graceful shutdown).
+func (bt *BudgetTracker) EnablePipelinedWrites(period time.Duration) {
+	bt.mu.Lock()
+	defer bt.mu.Unlock()
+
+	if bt.batcher != nil {
+		bt.batcher.Stop()
+	}
+	bt.batcher = newPipelineBatcher(bt.redisClient, period)
+}
+
+// Flush drains any buffered pipelined writes immediately. A no-op when
+// pipelined writes aren't enabled.
+func (bt *BudgetTracker) Flush(ctx context.Context) error {
+	bt.mu.RLock()
+	batcher := bt.batcher
+	bt.mu.RUnlock()
+
+	if batcher == nil {
+		return nil
+	}
+	return batcher.Flush(ctx)
+}
+
+// Close drains and stops the pipelined writer, if enabled. Call during
+// graceful shutdown so no buffered spend is lost.
+func (bt *BudgetTracker) Close() {
+	bt.mu.Lock()
+	batcher := bt.batcher
+	bt.batcher = nil
+	bt.mu.Unlock()
+
+	if batcher != nil {
+		batcher.Stop()
 	}
 }
 
@@ -41,18 +140,32 @@ func (bt *BudgetTracker) TrackSpend(ctx context.Context, campaignID string, amou
 	hourKey := bt.getHourKey(campaignID, now)
 	totalKey := bt.getTotalKey(campaignID)
 
+	bt.mu.RLock()
+	batcher := bt.batcher
+	bt.mu.RUnlock()
+
+	if batcher != nil {
+		batcher.Add(dayKey, 25*time.Hour, amount)
+		batcher.Add(hourKey, 2*time.Hour, amount)
+		batcher.Add(totalKey, 30*24*time.Hour, amount)
+		bt.invalidateCache(campaignID)
+		return nil
+	}
+
 	pipe := bt.redisClient.Pipeline()
-	
+
 	pipe.IncrBy(ctx, dayKey, amount)
 	pipe.Expire(ctx, dayKey, 25*time.Hour)
-	
+
 	pipe.IncrBy(ctx, hourKey, amount)
 	pipe.Expire(ctx, hourKey, 2*time.Hour)
-	
+
 	pipe.IncrBy(ctx, totalKey, amount)
 	pipe.Expire(ctx, totalKey, 30*24*time.Hour)
 
+	done := observeRedisPipeline("track_spend")
 	_, err := pipe.Exec(ctx)
+	done()
 	if err != nil {
 		log.WithError(err).Error("Failed to track spend in Redis")
 		return err
@@ -73,6 +186,9 @@ func (bt *BudgetTracker) GetBudgetStatus(ctx context.Context, campaignID string,
 	}
 	bt.mu.RUnlock()
 
+	ctx, cancel := bt.withOpDeadline(ctx)
+	defer cancel()
+
 	now := time.Now()
 	dayKey := bt.getDayKey(campaignID, now)
 	hourKey := bt.getHourKey(campaignID, now)
@@ -80,11 +196,21 @@ func (bt *BudgetTracker) GetBudgetStatus(ctx context.Context, campaignID string,
 	pipe := bt.redisClient.Pipeline()
 	dayCmd := pipe.Get(ctx, dayKey)
 	hourCmd := pipe.Get(ctx, hourKey)
+	done := observeRedisPipeline("get_budget_status")
 	_, err := pipe.Exec(ctx)
+	done()
 
 	var dailySpent, hourlySpent int64
 
 	if err != nil && err != redis.Nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			if stale, ok := bt.staleCache(campaignID); ok {
+				degraded := *stale
+				degraded.DegradedMode = true
+				degraded.Warning = "redis budget lookup exceeded decision deadline; serving stale cached status"
+				return &degraded, nil
+			}
+		}
 		log.WithError(err).Error("Failed to get budget status from Redis")
 		return nil, err
 	}
@@ -121,6 +247,23 @@ func (bt *BudgetTracker) BatchTrackSpend(ctx context.Context, spends map[string]
 	}
 
 	now := time.Now()
+
+	bt.mu.RLock()
+	batcher := bt.batcher
+	bt.mu.RUnlock()
+
+	if batcher != nil {
+		for campaignID, amount := range spends {
+			batcher.Add(bt.getDayKey(campaignID, now), 25*time.Hour, amount)
+			batcher.Add(bt.getHourKey(campaignID, now), 2*time.Hour, amount)
+			batcher.Add(bt.getTotalKey(campaignID), 30*24*time.Hour, amount)
+		}
+		for campaignID := range spends {
+			bt.invalidateCache(campaignID)
+		}
+		return nil
+	}
+
 	pipe := bt.redisClient.Pipeline()
 
 	for campaignID, amount := range spends {
@@ -130,15 +273,17 @@ func (bt *BudgetTracker) BatchTrackSpend(ctx context.Context, spends map[string]
 
 		pipe.IncrBy(ctx, dayKey, amount)
 		pipe.Expire(ctx, dayKey, 25*time.Hour)
-		
+
 		pipe.IncrBy(ctx, hourKey, amount)
 		pipe.Expire(ctx, hourKey, 2*time.Hour)
-		
+
 		pipe.IncrBy(ctx, totalKey, amount)
 		pipe.Expire(ctx, totalKey, 30*24*time.Hour)
 	}
 
+	done := observeRedisPipeline("batch_track_spend")
 	_, err := pipe.Exec(ctx)
+	done()
 	if err != nil {
 		log.WithError(err).Error("Failed to batch track spend")
 		return err
@@ -225,6 +370,17 @@ func (bt *BudgetTracker) getTotalKey(campaignID string) string {
 	return fmt.Sprintf("budget:total:%s", campaignID)
 }
 
+// staleCache returns campaignID's last cached BudgetStatus regardless of
+// cacheTTL, for degraded fallback when a fresh Redis read can't complete
+// in time. The second return value is false if nothing has been cached
+// for this campaign yet.
+func (bt *BudgetTracker) staleCache(campaignID string) (*BudgetStatus, bool) {
+	bt.mu.RLock()
+	defer bt.mu.RUnlock()
+	status, exists := bt.cache[campaignID]
+	return status, exists
+}
+
 func (bt *BudgetTracker) invalidateCache(campaignID string) {
 	bt.mu.Lock()
 	delete(bt.cache, campaignID)
@@ -0,0 +1,92 @@
+package pacer
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+// hangingRedisListener accepts TCP connections but never replies, standing
+// in for a Redis instance that's stalled long enough to blow the decision
+// deadline.
+func hangingRedisListener(t *testing.T) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to start fake redis listener: %v", err)
+	}
+	t.Cleanup(func() { lis.Close() })
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			// Accept and hold the connection open without responding.
+			t.Cleanup(func() { conn.Close() })
+		}
+	}()
+
+	return lis.Addr().String()
+}
+
+func TestGetBudgetStatus_FallsBackToStaleCacheOnDeadline(t *testing.T) {
+	addr := hangingRedisListener(t)
+	bt := NewBudgetTracker(addr)
+	bt.SetDeadline(20 * time.Millisecond)
+
+	cached := &BudgetStatus{CampaignID: "camp-1", DailySpent: 500}
+	bt.mu.Lock()
+	bt.cache["camp-1"] = cached
+	bt.mu.Unlock()
+	// Force the cache entry to be treated as stale so GetBudgetStatus
+	// attempts (and times out on) a fresh Redis read.
+	bt.cacheTTL = 0
+
+	status, err := bt.GetBudgetStatus(context.Background(), "camp-1", 10000)
+	if err != nil {
+		t.Fatalf("expected degraded fallback, got error: %v", err)
+	}
+	if !status.DegradedMode {
+		t.Fatalf("expected DegradedMode status, got %+v", status)
+	}
+	if status.Warning == "" {
+		t.Fatalf("expected a Warning message on degraded status")
+	}
+	if status.DailySpent != 500 {
+		t.Fatalf("expected stale cached DailySpent=500, got %d", status.DailySpent)
+	}
+}
+
+func TestGetBudgetStatus_ReturnsErrorOnDeadlineWithNoCache(t *testing.T) {
+	addr := hangingRedisListener(t)
+	bt := NewBudgetTracker(addr)
+	bt.SetDeadline(20 * time.Millisecond)
+
+	_, err := bt.GetBudgetStatus(context.Background(), "camp-uncached", 10000)
+	if err == nil {
+		t.Fatalf("expected an error when no stale cache is available to fall back to")
+	}
+}
+
+func TestWithOpDeadline_RespectsExistingContextDeadline(t *testing.T) {
+	bt := NewBudgetTracker("127.0.0.1:0")
+	bt.SetDeadline(time.Hour)
+
+	parent, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+
+	ctx, cancelOp := bt.withOpDeadline(parent)
+	defer cancelOp()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatalf("expected ctx to carry a deadline")
+	}
+	if time.Until(deadline) > time.Hour {
+		t.Fatalf("expected parent's shorter deadline to be preserved, not overridden by SetDeadline")
+	}
+}
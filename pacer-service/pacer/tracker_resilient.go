@@ -3,66 +3,89 @@ package pacer
 import (
 	"context"
 	"fmt"
-	"strconv"
 	"sync"
 	"time"
 
-	"github.com/go-redis/redis/v8"
 	log "github.com/sirupsen/logrus"
 )
 
-// MemoryBudget stores in-memory budget tracking
-type MemoryBudget struct {
-	DailySpent   int64
-	HourlySpent  int64
-	LastUpdate   time.Time
-	CurrentHour  int
-}
-
 // ResilientBudgetTracker extends BudgetTracker with fallback capabilities
 type ResilientBudgetTracker struct {
-	redisClient    *redis.Client
+	store          BudgetStore
 	memoryCache    map[string]*MemoryBudget
 	mu             sync.RWMutex
 	degradedMode   bool
 	lastRedisCheck time.Time
-	redisHealthy   bool
-	campaigns      map[string]int64 // campaign budgets for fallback
+	storeBreaker   storeCircuitBreaker      // CLOSED/OPEN/HALF_OPEN gate in front of store, with backoff; its zero value is a usable closed breaker
+	campaigns      map[string]int64         // campaign budgets for fallback
 	recoveryQueue  map[string]*MemoryBudget // data pending sync to Redis
 	recoveryMu     sync.Mutex
+
+	batcher *resilientPipeBatcher // non-nil when ResilientOptions.PipePeriod > 0
 }
 
-// NewResilientBudgetTracker creates a tracker with Redis failure handling
-func NewResilientBudgetTracker(redisAddr string) *ResilientBudgetTracker {
-	rdb := redis.NewClient(&redis.Options{
-		Addr:         redisAddr,
-		Password:     "",
-		DB:           0,
-		PoolSize:     100,
-		MinIdleConns: 10,
-		MaxRetries:   2,
-		DialTimeout:  1 * time.Second,
-		ReadTimeout:  1 * time.Second,
-		WriteTimeout: 1 * time.Second,
-	})
+// NewResilientBudgetTracker creates a tracker with store-failure handling.
+// It spawns one goroutine per TrackSpend call to read-modify-write the
+// campaign-day's BudgetRecord and increment its lifetime total; use
+// NewResilientBudgetTrackerWithOptions to enable batching under load.
+func NewResilientBudgetTracker(store BudgetStore) *ResilientBudgetTracker {
+	return NewResilientBudgetTrackerWithOptions(store, ResilientOptions{})
+}
 
+// NewResilientBudgetTrackerWithOptions creates a tracker with store-failure
+// handling and the tunables in opts. Set opts.PipePeriod to enable the
+// batched pipeline writer. store is typically built with
+// NewRedisBudgetStore, NewMemoryBudgetStore, or NewNullBudgetStore.
+func NewResilientBudgetTrackerWithOptions(store BudgetStore, opts ResilientOptions) *ResilientBudgetTracker {
 	tracker := &ResilientBudgetTracker{
-		redisClient:   rdb,
+		store:         store,
 		memoryCache:   make(map[string]*MemoryBudget),
 		campaigns:     make(map[string]int64),
 		recoveryQueue: make(map[string]*MemoryBudget),
-		redisHealthy:  true,
 	}
 
+	if opts.PipePeriod > 0 {
+		tracker.batcher = newResilientPipeBatcher(tracker, opts)
+	}
+
+	tracker.storeBreaker.OnStateChange(tracker.logBreakerStateChange)
+
 	// Start recovery goroutine
 	go tracker.autoRecoveryLoop()
-	
+
 	// Start Redis health checker
 	go tracker.healthCheckLoop()
 
 	return tracker
 }
 
+// Close drains and stops the batched pipeline writer, if enabled. Call
+// during graceful shutdown so no buffered spend is lost.
+func (bt *ResilientBudgetTracker) Close() {
+	bt.mu.Lock()
+	batcher := bt.batcher
+	bt.batcher = nil
+	bt.mu.Unlock()
+
+	if batcher != nil {
+		batcher.Stop()
+	}
+}
+
+// Flush drains any buffered batched increments to Redis immediately,
+// without waiting for the next PipePeriod tick. A no-op if batching isn't
+// enabled. Intended for tests.
+func (bt *ResilientBudgetTracker) Flush(ctx context.Context) error {
+	bt.mu.RLock()
+	batcher := bt.batcher
+	bt.mu.RUnlock()
+
+	if batcher == nil {
+		return nil
+	}
+	return batcher.Flush(ctx)
+}
+
 // SetCampaignBudgets updates known campaign budgets for fallback mode
 func (bt *ResilientBudgetTracker) SetCampaignBudgets(campaigns map[string]int64) {
 	bt.mu.Lock()
@@ -73,21 +96,33 @@ func (bt *ResilientBudgetTracker) SetCampaignBudgets(campaigns map[string]int64)
 // TrackSpend tracks spending with fallback to memory if Redis fails
 func (bt *ResilientBudgetTracker) TrackSpend(ctx context.Context, campaignID string, amount int64) error {
 	now := time.Now()
-	
+
 	// Always update memory cache first
 	bt.updateMemoryCache(campaignID, amount, now)
-	
-	// Try to update Redis asynchronously
-	go bt.asyncRedisUpdate(campaignID, amount, now)
-	
+
+	bt.mu.RLock()
+	batcher := bt.batcher
+	bt.mu.RUnlock()
+
+	if batcher != nil {
+		// Batching enabled: coalesce this increment with others and let the
+		// background flush loop write it to Redis.
+		batcher.Add(campaignID, amount, now)
+	} else {
+		// Try to update Redis asynchronously
+		go bt.asyncStoreUpdate(campaignID, amount, now)
+	}
+
 	return nil // Never fail on tracking
 }
 
-// updateMemoryCache updates the in-memory cache
-func (bt *ResilientBudgetTracker) updateMemoryCache(campaignID string, amount int64, now time.Time) {
+// getOrCreateMemoryBudget returns campaignID's cache entry, creating it
+// under bt.mu (which guards the map itself, not the entry's own fields) if
+// this is the first time campaignID has been seen.
+func (bt *ResilientBudgetTracker) getOrCreateMemoryBudget(campaignID string, now time.Time) *MemoryBudget {
 	bt.mu.Lock()
 	defer bt.mu.Unlock()
-	
+
 	budget, exists := bt.memoryCache[campaignID]
 	if !exists {
 		budget = &MemoryBudget{
@@ -96,97 +131,117 @@ func (bt *ResilientBudgetTracker) updateMemoryCache(campaignID string, amount in
 		}
 		bt.memoryCache[campaignID] = budget
 	}
-	
-	// Reset hourly if hour changed
-	if budget.CurrentHour != now.Hour() {
-		budget.HourlySpent = 0
-		budget.CurrentHour = now.Hour()
-	}
-	
+	return budget
+}
+
+// memoryBudgetFor returns campaignID's cache entry without creating one.
+func (bt *ResilientBudgetTracker) memoryBudgetFor(campaignID string) (*MemoryBudget, bool) {
+	bt.mu.RLock()
+	defer bt.mu.RUnlock()
+	budget, exists := bt.memoryCache[campaignID]
+	return budget, exists
+}
+
+// updateMemoryCache updates the in-memory cache. budget.mu (not bt.mu)
+// guards the actual counters, so this can run concurrently with
+// TrackSpend/GetBudgetStatus calls for other campaigns, and the recovery
+// snapshot queued below is a value copy -- never the live *MemoryBudget --
+// so syncMemoryToStore can't observe a half-updated entry.
+func (bt *ResilientBudgetTracker) updateMemoryCache(campaignID string, amount int64, now time.Time) {
+	budget := bt.getOrCreateMemoryBudget(campaignID, now)
+
+	budget.mu.Lock()
+	resetHourlyIfChanged(budget, now)
 	budget.DailySpent += amount
 	budget.HourlySpent += amount
 	budget.LastUpdate = now
-	
+	snapshot := &MemoryBudget{
+		DailySpent:  budget.DailySpent,
+		HourlySpent: budget.HourlySpent,
+		LastUpdate:  budget.LastUpdate,
+		CurrentHour: budget.CurrentHour,
+	}
+	budget.mu.Unlock()
+
 	// Add to recovery queue if in degraded mode
-	if bt.degradedMode {
+	bt.mu.RLock()
+	degraded := bt.degradedMode
+	bt.mu.RUnlock()
+	if degraded {
 		bt.recoveryMu.Lock()
-		bt.recoveryQueue[campaignID] = budget
+		bt.recoveryQueue[campaignID] = snapshot
 		bt.recoveryMu.Unlock()
 	}
 }
 
-// asyncRedisUpdate tries to update Redis without blocking
-func (bt *ResilientBudgetTracker) asyncRedisUpdate(campaignID string, amount int64, now time.Time) {
-	if !bt.redisHealthy {
-		return // Skip if we know Redis is down
+// asyncStoreUpdate tries to update the store without blocking
+func (bt *ResilientBudgetTracker) asyncStoreUpdate(campaignID string, amount int64, now time.Time) {
+	if !bt.storeBreaker.AllowRequest() {
+		return // Circuit open (or a half-open probe already in flight)
 	}
-	
+
 	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
 	defer cancel()
-	
-	dayKey := bt.getDayKey(campaignID, now)
-	hourKey := bt.getHourKey(campaignID, now)
+
+	rec, err := bt.loadRecord(ctx, campaignID, now)
+	if err != nil {
+		bt.handleRedisFailure(err)
+		return
+	}
+	rec.DailySpentCents += amount
+	applyHourlyDelta(rec, now.Hour(), amount)
+	rec.LastUpdateUnix = now.Unix()
+	rec.Version++
+
+	if err := bt.saveRecord(ctx, campaignID, now, rec); err != nil {
+		bt.handleRedisFailure(err)
+		return
+	}
+
 	totalKey := bt.getTotalKey(campaignID)
-	
-	pipe := bt.redisClient.Pipeline()
-	pipe.IncrBy(ctx, dayKey, amount)
-	pipe.Expire(ctx, dayKey, 25*time.Hour)
-	pipe.IncrBy(ctx, hourKey, amount)
-	pipe.Expire(ctx, hourKey, 2*time.Hour)
+	pipe := bt.store.Pipeline()
 	pipe.IncrBy(ctx, totalKey, amount)
 	pipe.Expire(ctx, totalKey, 30*24*time.Hour)
-	
-	if _, err := pipe.Exec(ctx); err != nil {
+	if err := pipe.Exec(ctx); err != nil {
 		bt.handleRedisFailure(err)
+		return
 	}
+	bt.storeBreaker.RecordSuccess()
 }
 
 // GetBudgetStatus gets status with fallback to memory cache
 func (bt *ResilientBudgetTracker) GetBudgetStatus(ctx context.Context, campaignID string, dailyBudget int64) (*BudgetStatus, error) {
 	now := time.Now()
-	
-	// Try Redis first if healthy
-	if bt.redisHealthy {
-		status, err := bt.getFromRedis(ctx, campaignID, dailyBudget, now)
+
+	// Try the store first if the breaker admits the attempt
+	if bt.storeBreaker.AllowRequest() {
+		status, err := bt.getFromStore(ctx, campaignID, dailyBudget, now)
 		if err == nil {
-			// Update memory cache with Redis data
+			bt.storeBreaker.RecordSuccess()
+			// Update memory cache with store data
 			bt.syncToMemory(campaignID, status)
 			return status, nil
 		}
 		bt.handleRedisFailure(err)
 	}
-	
+
 	// Fallback to memory cache
 	return bt.getFromMemory(campaignID, dailyBudget, now), nil
 }
 
-// getFromRedis attempts to get budget status from Redis
-func (bt *ResilientBudgetTracker) getFromRedis(ctx context.Context, campaignID string, dailyBudget int64, now time.Time) (*BudgetStatus, error) {
-	dayKey := bt.getDayKey(campaignID, now)
-	hourKey := bt.getHourKey(campaignID, now)
-	
-	pipe := bt.redisClient.Pipeline()
-	dayCmd := pipe.Get(ctx, dayKey)
-	hourCmd := pipe.Get(ctx, hourKey)
-	
-	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+// getFromStore attempts to get budget status from the backing store
+func (bt *ResilientBudgetTracker) getFromStore(ctx context.Context, campaignID string, dailyBudget int64, now time.Time) (*BudgetStatus, error) {
+	rec, err := bt.loadRecord(ctx, campaignID, now)
+	if err != nil {
 		return nil, err
 	}
-	
-	var dailySpent, hourlySpent int64
-	if dayCmd.Val() != "" {
-		dailySpent, _ = strconv.ParseInt(dayCmd.Val(), 10, 64)
-	}
-	if hourCmd.Val() != "" {
-		hourlySpent, _ = strconv.ParseInt(hourCmd.Val(), 10, 64)
-	}
-	
+
 	return &BudgetStatus{
 		CampaignID:     campaignID,
 		DailyBudget:    dailyBudget,
-		DailySpent:     dailySpent,
+		DailySpent:     rec.DailySpentCents,
 		HourlyBudget:   dailyBudget / 24,
-		HourlySpent:    hourlySpent,
+		HourlySpent:    hourlySpent(rec, now.Hour()),
 		RemainingHours: 24 - now.Hour(),
 		CurrentHour:    now.Hour(),
 		ThrottleRate:   0.0,
@@ -196,34 +251,31 @@ func (bt *ResilientBudgetTracker) getFromRedis(ctx context.Context, campaignID s
 
 // getFromMemory creates budget status from memory cache
 func (bt *ResilientBudgetTracker) getFromMemory(campaignID string, dailyBudget int64, now time.Time) *BudgetStatus {
-	bt.mu.RLock()
-	defer bt.mu.RUnlock()
-	
 	// Get from memory cache
-	if budget, exists := bt.memoryCache[campaignID]; exists {
-		// Reset hourly if hour changed
-		hourlySpent := budget.HourlySpent
-		if budget.CurrentHour != now.Hour() {
-			hourlySpent = 0
-		}
-		
+	if budget, exists := bt.memoryBudgetFor(campaignID); exists {
+		budget.mu.Lock()
+		resetHourlyIfChanged(budget, now) // applied here too, not just on the next TrackSpend, so readers never see a stale hourly value across the boundary
+		dailySpent := budget.DailySpent
+		hourSpent := budget.HourlySpent
+		budget.mu.Unlock()
+
 		return &BudgetStatus{
 			CampaignID:     campaignID,
 			DailyBudget:    dailyBudget,
-			DailySpent:     budget.DailySpent,
+			DailySpent:     dailySpent,
 			HourlyBudget:   dailyBudget / 24,
-			HourlySpent:    hourlySpent,
+			HourlySpent:    hourSpent,
 			RemainingHours: 24 - now.Hour(),
 			CurrentHour:    now.Hour(),
 			ThrottleRate:   0.5, // Conservative throttle in degraded mode
 			DegradedMode:   true,
 		}
 	}
-	
+
 	// No cache - return conservative estimate
 	hoursPassed := now.Hour()
 	assumedSpent := (dailyBudget * int64(hoursPassed)) / 24
-	
+
 	return &BudgetStatus{
 		CampaignID:     campaignID,
 		DailyBudget:    dailyBudget,
@@ -241,7 +293,7 @@ func (bt *ResilientBudgetTracker) getFromMemory(campaignID string, dailyBudget i
 func (bt *ResilientBudgetTracker) syncToMemory(campaignID string, status *BudgetStatus) {
 	bt.mu.Lock()
 	defer bt.mu.Unlock()
-	
+
 	bt.memoryCache[campaignID] = &MemoryBudget{
 		DailySpent:  status.DailySpent,
 		HourlySpent: status.HourlySpent,
@@ -250,48 +302,65 @@ func (bt *ResilientBudgetTracker) syncToMemory(campaignID string, status *Budget
 	}
 }
 
-// handleRedisFailure marks Redis as unhealthy
+// handleRedisFailure records a store failure against storeBreaker (tripping
+// it to OPEN once the failure threshold is reached) and marks the tracker
+// degraded so reads and the recovery loop prefer the memory cache.
 func (bt *ResilientBudgetTracker) handleRedisFailure(err error) {
-	log.WithError(err).Warn("Redis operation failed, entering degraded mode")
+	log.WithError(err).Warn("Budget store operation failed, entering degraded mode")
 	bt.mu.Lock()
 	bt.degradedMode = true
-	bt.redisHealthy = false
 	bt.mu.Unlock()
+	bt.storeBreaker.RecordFailure()
 }
 
-// healthCheckLoop periodically checks Redis health
+// logBreakerStateChange is storeBreaker's OnStateChange hook: it logs
+// transitions so an operator tailing logs sees the same state changes the
+// Prometheus gauge reports.
+func (bt *ResilientBudgetTracker) logBreakerStateChange(from, to StoreCircuitState) {
+	if to == StoreCircuitClosed {
+		log.Info("Budget store connection restored")
+		return
+	}
+	log.WithFields(log.Fields{"from": from, "to": to}).Warn("Budget store circuit breaker changed state")
+}
+
+// healthCheckLoop periodically probes the backing store through storeBreaker
+// so a store that recovers without any TrackSpend/GetBudgetStatus traffic
+// still gets its half-open trial requests; AllowRequest ensures at most one
+// probe is in flight at a time, regardless of how many goroutines call in.
 func (bt *ResilientBudgetTracker) healthCheckLoop() {
 	ticker := time.NewTicker(5 * time.Second)
 	defer ticker.Stop()
-	
+
 	for range ticker.C {
+		if !bt.storeBreaker.AllowRequest() {
+			continue
+		}
+
 		ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
-		err := bt.redisClient.Ping(ctx).Err()
+		err := bt.store.Ping(ctx)
 		cancel()
-		
-		bt.mu.Lock()
-		wasUnhealthy := !bt.redisHealthy
-		bt.redisHealthy = (err == nil)
-		bt.mu.Unlock()
-		
-		if wasUnhealthy && bt.redisHealthy {
-			log.Info("Redis connection restored")
+
+		if err != nil {
+			bt.storeBreaker.RecordFailure()
+			continue
 		}
+		bt.storeBreaker.RecordSuccess()
 	}
 }
 
-// autoRecoveryLoop attempts to sync memory data back to Redis
+// autoRecoveryLoop attempts to sync memory data back to the store
 func (bt *ResilientBudgetTracker) autoRecoveryLoop() {
 	ticker := time.NewTicker(10 * time.Second)
 	defer ticker.Stop()
-	
+
 	for range ticker.C {
-		if !bt.degradedMode || !bt.redisHealthy {
+		if !bt.degradedMode || bt.storeBreaker.State() != StoreCircuitClosed {
 			continue
 		}
-		
+
 		log.Info("Attempting to recover from degraded mode...")
-		if err := bt.syncMemoryToRedis(); err != nil {
+		if err := bt.syncMemoryToStore(); err != nil {
 			log.WithError(err).Warn("Recovery failed, will retry")
 		} else {
 			bt.mu.Lock()
@@ -302,35 +371,48 @@ func (bt *ResilientBudgetTracker) autoRecoveryLoop() {
 	}
 }
 
-// syncMemoryToRedis syncs accumulated memory data back to Redis
-func (bt *ResilientBudgetTracker) syncMemoryToRedis() error {
+// syncMemoryToStore syncs accumulated memory data back to the store
+func (bt *ResilientBudgetTracker) syncMemoryToStore() error {
 	bt.recoveryMu.Lock()
 	queue := bt.recoveryQueue
 	bt.recoveryQueue = make(map[string]*MemoryBudget)
 	bt.recoveryMu.Unlock()
-	
+
 	if len(queue) == 0 {
 		return nil
 	}
-	
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
-	
+
 	now := time.Now()
-	pipe := bt.redisClient.Pipeline()
-	
+	pipe := bt.store.Pipeline()
+
+	var recordErr error
 	for campaignID, budget := range queue {
-		dayKey := bt.getDayKey(campaignID, now)
-		hourKey := bt.getHourKey(campaignID, now)
-		totalKey := bt.getTotalKey(campaignID)
-		
-		// Set absolute values instead of incrementing
-		pipe.Set(ctx, dayKey, budget.DailySpent, 25*time.Hour)
-		pipe.Set(ctx, hourKey, budget.HourlySpent, 2*time.Hour)
-		pipe.IncrBy(ctx, totalKey, budget.DailySpent)
+		rec, err := bt.loadRecord(ctx, campaignID, now)
+		if err != nil {
+			recordErr = fmt.Errorf("failed to sync record for %s to store: %w", campaignID, err)
+			break
+		}
+		// Set absolute values instead of incrementing, same as the memory
+		// cache snapshot being restored.
+		rec.DailySpentCents = budget.DailySpent
+		setHourlySpent(rec, budget.CurrentHour, budget.HourlySpent)
+		rec.LastUpdateUnix = now.Unix()
+		rec.Version++
+		if err := bt.saveRecord(ctx, campaignID, now, rec); err != nil {
+			recordErr = fmt.Errorf("failed to sync record for %s to store: %w", campaignID, err)
+			break
+		}
+
+		pipe.IncrBy(ctx, bt.getTotalKey(campaignID), budget.DailySpent)
+	}
+
+	err := recordErr
+	if err == nil {
+		err = pipe.Exec(ctx)
 	}
-	
-	_, err := pipe.Exec(ctx)
 	if err != nil {
 		// Put items back in queue
 		bt.recoveryMu.Lock()
@@ -338,10 +420,10 @@ func (bt *ResilientBudgetTracker) syncMemoryToRedis() error {
 			bt.recoveryQueue[k] = v
 		}
 		bt.recoveryMu.Unlock()
-		return fmt.Errorf("failed to sync to Redis: %w", err)
+		return fmt.Errorf("failed to sync to store: %w", err)
 	}
-	
-	log.WithField("campaigns_synced", len(queue)).Info("Successfully synced memory cache to Redis")
+
+	log.WithField("campaigns_synced", len(queue)).Info("Successfully synced memory cache to store")
 	return nil
 }
 
@@ -356,25 +438,19 @@ func (bt *ResilientBudgetTracker) IsHealthy() bool {
 func (bt *ResilientBudgetTracker) GetHealthStatus() map[string]interface{} {
 	bt.mu.RLock()
 	defer bt.mu.RUnlock()
-	
+
+	state := bt.storeBreaker.State()
 	return map[string]interface{}{
-		"redis_healthy":    bt.redisHealthy,
-		"degraded_mode":    bt.degradedMode,
-		"memory_cache_size": len(bt.memoryCache),
-		"recovery_queue":   len(bt.recoveryQueue),
-		"last_redis_check": bt.lastRedisCheck,
+		"redis_healthy":       state == StoreCircuitClosed,
+		"store_circuit_state": string(state),
+		"degraded_mode":       bt.degradedMode,
+		"memory_cache_size":   len(bt.memoryCache),
+		"recovery_queue":      len(bt.recoveryQueue),
+		"last_redis_check":    bt.lastRedisCheck,
 	}
 }
 
 // Helper methods for Redis keys
-func (bt *ResilientBudgetTracker) getDayKey(campaignID string, t time.Time) string {
-	return fmt.Sprintf("budget:day:%s:%s", campaignID, t.Format("2006-01-02"))
-}
-
-func (bt *ResilientBudgetTracker) getHourKey(campaignID string, t time.Time) string {
-	return fmt.Sprintf("budget:hour:%s:%s", campaignID, t.Format("2006-01-02-15"))
-}
-
 func (bt *ResilientBudgetTracker) getTotalKey(campaignID string) string {
 	return fmt.Sprintf("budget:total:%s", campaignID)
 }
@@ -387,7 +463,7 @@ func (bt *ResilientBudgetTracker) getTotalKey(campaignID string) string {
 func GetDegradedDecision(campaign *Campaign, bidCents int64) PacingDecisionResponse {
 	hour := time.Now().Hour()
 	hoursRemaining := 24 - hour
-	
+
 	// Base throttle rate depends on time of day
 	var throttleRate float64
 	switch {
@@ -398,16 +474,16 @@ func GetDegradedDecision(campaign *Campaign, bidCents int64) PacingDecisionRespo
 	default:
 		throttleRate = 0.6
 	}
-	
+
 	// Conservative max bid
 	safeMaxBid := campaign.DailyBudget / int64(hoursRemaining*100)
 	if safeMaxBid > bidCents/2 {
 		safeMaxBid = bidCents / 2
 	}
-	
+
 	// Random throttling
 	allowBid := rand.Float64() > throttleRate
-	
+
 	return PacingDecisionResponse{
 		AllowBid:     allowBid,
 		MaxBidCents:  safeMaxBid,
@@ -416,4 +492,4 @@ func GetDegradedDecision(campaign *Campaign, bidCents int64) PacingDecisionRespo
 		Warning:      "Operating in degraded mode without real-time spend data",
 	}
 }
-*/
\ No newline at end of file
+*/
@@ -0,0 +1,224 @@
+package pacer
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ResilientOptions configures optional ResilientBudgetTracker behavior
+// beyond its always-on memory fallback.
+type ResilientOptions struct {
+	// PipePeriod enables the batched Redis pipeline writer when > 0:
+	// TrackSpend increments are coalesced in an in-memory delta map and
+	// flushed as a single batch every PipePeriod, instead of
+	// asyncStoreUpdate spawning one goroutine per call. Zero (the default)
+	// disables batching and preserves the original per-call behavior.
+	PipePeriod time.Duration
+	// MaxInFlight forces an early flush once this many distinct delta keys
+	// are buffered, bounding memory and pipeline size under a burst.
+	// Defaults to 10000.
+	MaxInFlight int
+}
+
+// resilientDeltaKey identifies one campaign's buffered budget:v2 record
+// and total-key for a pending batched flush. hour is the hour-of-day the
+// buffered amount belongs to, since a single campaign-day record can carry
+// deltas for more than one hour within a batch.
+type resilientDeltaKey struct {
+	campaignID string
+	date       string
+	hour       int
+}
+
+// resilientPipeBatcher is ResilientBudgetTracker's opt-in batched writer: it
+// coalesces TrackSpend increments per (campaignID, date, hour) and flushes
+// them as one batch of record read-modify-writes plus one total-key
+// pipeline every PipePeriod instead of a round trip per call, falling back
+// to the tracker's existing recovery queue if the flush fails.
+type resilientPipeBatcher struct {
+	tracker *ResilientBudgetTracker
+
+	mu          sync.Mutex
+	deltas      map[resilientDeltaKey]int64
+	maxInFlight int
+
+	period time.Duration
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+func newResilientPipeBatcher(tracker *ResilientBudgetTracker, opts ResilientOptions) *resilientPipeBatcher {
+	maxInFlight := opts.MaxInFlight
+	if maxInFlight <= 0 {
+		maxInFlight = 10000
+	}
+
+	b := &resilientPipeBatcher{
+		tracker:     tracker,
+		deltas:      make(map[resilientDeltaKey]int64),
+		maxInFlight: maxInFlight,
+		period:      opts.PipePeriod,
+		stopCh:      make(chan struct{}),
+		doneCh:      make(chan struct{}),
+	}
+	go b.loop()
+	return b
+}
+
+func (b *resilientPipeBatcher) loop() {
+	defer close(b.doneCh)
+
+	ticker := time.NewTicker(b.period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			_ = b.Flush(context.Background())
+		case <-b.stopCh:
+			_ = b.Flush(context.Background())
+			return
+		}
+	}
+}
+
+// Add buffers amount against campaignID's campaign-day/hour record and
+// total key, coalescing with any other increments buffered since the last
+// flush. If buffering this increment would introduce more than MaxInFlight
+// distinct keys, it flushes immediately first.
+func (b *resilientPipeBatcher) Add(campaignID string, amount int64, now time.Time) {
+	key := resilientDeltaKey{
+		campaignID: campaignID,
+		date:       now.Format("2006-01-02"),
+		hour:       now.Hour(),
+	}
+
+	b.mu.Lock()
+	if _, exists := b.deltas[key]; !exists && len(b.deltas) >= b.maxInFlight {
+		b.mu.Unlock()
+		_ = b.Flush(context.Background())
+		b.mu.Lock()
+	}
+	b.deltas[key] += amount
+	b.mu.Unlock()
+}
+
+// Flush drains the buffered deltas and writes them as one record
+// read-modify-write per campaign-day plus one pipeline for the lifetime
+// totals. On failure (or if Redis is already known unhealthy), it
+// redirects every buffered campaign's current memory snapshot into the
+// tracker's recovery queue instead of dropping the increments. Safe to call concurrently
+// with Add and with the background flush loop; callers use it directly for
+// shutdown draining and in tests.
+func (b *resilientPipeBatcher) Flush(ctx context.Context) error {
+	b.mu.Lock()
+	deltas := b.deltas
+	b.deltas = make(map[resilientDeltaKey]int64)
+	b.mu.Unlock()
+
+	if len(deltas) == 0 {
+		return nil
+	}
+
+	if !b.tracker.storeBreaker.AllowRequest() {
+		b.redirectToRecovery(deltas)
+		return nil
+	}
+
+	pctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	// Group buffered deltas by campaign-day so every hour's increment
+	// within a batch is folded into a single record read-modify-write,
+	// then fold each campaign's per-day totals into one IncrBy.
+	type campaignDay struct {
+		campaignID string
+		date       string
+	}
+	byRecord := make(map[campaignDay]map[int]int64, len(deltas))
+	totals := make(map[string]int64, len(deltas))
+	for key, amount := range deltas {
+		cd := campaignDay{campaignID: key.campaignID, date: key.date}
+		hours, ok := byRecord[cd]
+		if !ok {
+			hours = make(map[int]int64)
+			byRecord[cd] = hours
+		}
+		hours[key.hour] += amount
+		totals[key.campaignID] += amount
+	}
+
+	var recordErr error
+	for cd, hours := range byRecord {
+		t, err := time.Parse("2006-01-02", cd.date)
+		if err != nil {
+			recordErr = err
+			break
+		}
+		rec, err := b.tracker.loadRecord(pctx, cd.campaignID, t)
+		if err != nil {
+			recordErr = err
+			break
+		}
+		for hour, amount := range hours {
+			rec.DailySpentCents += amount
+			applyHourlyDelta(rec, hour, amount)
+		}
+		rec.LastUpdateUnix = time.Now().Unix()
+		rec.Version++
+		if err := b.tracker.saveRecord(pctx, cd.campaignID, t, rec); err != nil {
+			recordErr = err
+			break
+		}
+	}
+
+	pipe := b.tracker.store.Pipeline()
+	for campaignID, amount := range totals {
+		totalKey := b.tracker.getTotalKey(campaignID)
+		pipe.IncrBy(pctx, totalKey, amount)
+		pipe.Expire(pctx, totalKey, 30*24*time.Hour)
+	}
+
+	done := observeRedisPipeline("resilient_batched_flush")
+	err := recordErr
+	if err == nil {
+		err = pipe.Exec(pctx)
+	}
+	done()
+	if err != nil {
+		b.tracker.handleRedisFailure(err)
+		b.redirectToRecovery(deltas)
+		return err
+	}
+	b.tracker.storeBreaker.RecordSuccess()
+	return nil
+}
+
+// redirectToRecovery pushes every campaign touched by a failed flush into
+// the tracker's recovery queue using a snapshot of its current memory
+// cache entry, so autoRecoveryLoop picks it up the same way it would after
+// any other Redis failure.
+func (b *resilientPipeBatcher) redirectToRecovery(deltas map[resilientDeltaKey]int64) {
+	seen := make(map[string]*MemoryBudget, len(deltas))
+	for key := range deltas {
+		if _, ok := seen[key.campaignID]; ok {
+			continue
+		}
+		if budget, ok := b.tracker.memoryBudgetFor(key.campaignID); ok {
+			seen[key.campaignID] = snapshotMemoryBudget(budget)
+		}
+	}
+
+	b.tracker.recoveryMu.Lock()
+	defer b.tracker.recoveryMu.Unlock()
+	for campaignID, snapshot := range seen {
+		b.tracker.recoveryQueue[campaignID] = snapshot
+	}
+}
+
+// Stop flushes any remaining deltas and stops the background flush loop.
+func (b *resilientPipeBatcher) Stop() {
+	close(b.stopCh)
+	<-b.doneCh
+}
@@ -0,0 +1,83 @@
+package pacer
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestResilientPipeBatcher_CoalescesSameKeyIncrements(t *testing.T) {
+	tracker := &ResilientBudgetTracker{
+		store:         NewMemoryBudgetStore(),
+		memoryCache:   make(map[string]*MemoryBudget),
+		recoveryQueue: make(map[string]*MemoryBudget),
+	}
+	b := newResilientPipeBatcher(tracker, ResilientOptions{PipePeriod: time.Hour})
+	defer b.Stop()
+
+	now := time.Now()
+	b.Add("camp-1", 100, now)
+	b.Add("camp-1", 50, now)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.deltas) != 1 {
+		t.Fatalf("expected one coalesced delta key, got %d", len(b.deltas))
+	}
+	for _, amount := range b.deltas {
+		if amount != 150 {
+			t.Fatalf("expected coalesced amount 150, got %d", amount)
+		}
+	}
+}
+
+func TestResilientPipeBatcher_FlushRedirectsToRecoveryWhenRedisUnhealthy(t *testing.T) {
+	tracker := &ResilientBudgetTracker{
+		memoryCache:   map[string]*MemoryBudget{"camp-1": {DailySpent: 500, HourlySpent: 100}},
+		recoveryQueue: make(map[string]*MemoryBudget),
+		storeBreaker:  tippedOpenStoreBreaker(),
+	}
+	b := newResilientPipeBatcher(tracker, ResilientOptions{PipePeriod: time.Hour})
+	defer b.Stop()
+
+	b.Add("camp-1", 25, time.Now())
+	if err := b.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+
+	tracker.recoveryMu.Lock()
+	defer tracker.recoveryMu.Unlock()
+	budget, ok := tracker.recoveryQueue["camp-1"]
+	if !ok {
+		t.Fatalf("expected camp-1 to be redirected to the recovery queue")
+	}
+	if budget.DailySpent != 500 {
+		t.Fatalf("expected recovery snapshot DailySpent 500, got %d", budget.DailySpent)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.deltas) != 0 {
+		t.Fatalf("expected deltas to be drained after flush, got %d", len(b.deltas))
+	}
+}
+
+func TestResilientPipeBatcher_AddForcesEarlyFlushAtMaxInFlight(t *testing.T) {
+	tracker := &ResilientBudgetTracker{
+		memoryCache:   make(map[string]*MemoryBudget),
+		recoveryQueue: make(map[string]*MemoryBudget),
+		storeBreaker:  tippedOpenStoreBreaker(), // force Flush to redirect instead of dialing Redis
+	}
+	b := newResilientPipeBatcher(tracker, ResilientOptions{PipePeriod: time.Hour, MaxInFlight: 1})
+	defer b.Stop()
+
+	now := time.Now()
+	b.Add("camp-1", 10, now)
+	b.Add("camp-2", 20, now)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.deltas) != 1 {
+		t.Fatalf("expected MaxInFlight to force a flush before buffering camp-2, got %d buffered keys", len(b.deltas))
+	}
+}
@@ -0,0 +1,56 @@
+package resilience
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// BulkheadPolicy caps concurrent in-flight calls per key (typically the
+// campaign ID) using a bounded semaphore, so one noisy campaign can't
+// starve the shared worker pool.
+type BulkheadPolicy struct {
+	MaxConcurrent int
+	// KeyFunc extracts the bulkhead key (e.g. campaign ID) from a request.
+	// Nil means every request shares a single bulkhead.
+	KeyFunc func(req interface{}) string
+	Events  *EventListener
+
+	mu   sync.Mutex
+	sems map[string]chan struct{}
+}
+
+func (p *BulkheadPolicy) semFor(key string) chan struct{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.sems == nil {
+		p.sems = make(map[string]chan struct{})
+	}
+	sem, exists := p.sems[key]
+	if !exists {
+		sem = make(chan struct{}, p.MaxConcurrent)
+		p.sems[key] = sem
+	}
+	return sem
+}
+
+func (p *BulkheadPolicy) Apply(next Decide) Decide {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		key := ""
+		if p.KeyFunc != nil {
+			key = p.KeyFunc(req)
+		}
+		sem := p.semFor(key)
+
+		select {
+		case sem <- struct{}{}:
+		default:
+			p.Events.bulkheadRejected(key)
+			return nil, fmt.Errorf("bulkhead: concurrency limit exceeded for %q", key)
+		}
+		defer func() { <-sem }()
+
+		return next(ctx, req)
+	}
+}
@@ -0,0 +1,38 @@
+package resilience
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ad-budget-pacer/pacer-service/pacer"
+)
+
+// CircuitBreakerPolicy wraps the existing pacer.CircuitBreaker as a
+// resilience Policy so it can be composed alongside retry/hedge/bulkhead
+// instead of being checked out-of-band in the handler.
+type CircuitBreakerPolicy struct {
+	Breaker *pacer.CircuitBreaker
+	// StatusFunc derives the BudgetStatus the breaker needs to evaluate
+	// Allow() from the incoming request.
+	StatusFunc func(req interface{}) *pacer.BudgetStatus
+	Events     *EventListener
+}
+
+func (p *CircuitBreakerPolicy) Apply(next Decide) Decide {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		status := p.StatusFunc(req)
+
+		if !p.Breaker.Allow(status) {
+			p.Events.circuitOpen(fmt.Sprintf("breaker open for campaign %q", status.CampaignID))
+			return nil, fmt.Errorf("circuit breaker open for campaign %q", status.CampaignID)
+		}
+
+		resp, err := next(ctx, req)
+		if err != nil {
+			p.Breaker.RecordFailure(err.Error())
+		} else {
+			p.Breaker.RecordSuccess()
+		}
+		return resp, err
+	}
+}
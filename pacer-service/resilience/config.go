@@ -0,0 +1,82 @@
+package resilience
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// CampaignPolicyConfig describes which policies should wrap pacing
+// decisions for a single campaign, loaded from JSON so budget-critical
+// campaigns can enable hedging + retries while low-value ones only get a
+// timeout and breaker.
+type CampaignPolicyConfig struct {
+	CampaignID string `json:"campaign_id"`
+
+	Retry *struct {
+		MaxAttempts int   `json:"max_attempts"`
+		BaseDelayMs int64 `json:"base_delay_ms"`
+		MaxDelayMs  int64 `json:"max_delay_ms"`
+	} `json:"retry,omitempty"`
+
+	Hedge *struct {
+		DelayMs   int64 `json:"delay_ms"`
+		MaxHedges int   `json:"max_hedges"`
+	} `json:"hedge,omitempty"`
+
+	Bulkhead *struct {
+		MaxConcurrent int `json:"max_concurrent"`
+	} `json:"bulkhead,omitempty"`
+
+	TimeoutMs int64 `json:"timeout_ms"`
+}
+
+// LoadCampaignPolicyConfigs parses a JSON array of CampaignPolicyConfig,
+// e.g. from a campaign_policies.json file shipped alongside the service
+// config.
+func LoadCampaignPolicyConfigs(data []byte) ([]CampaignPolicyConfig, error) {
+	var configs []CampaignPolicyConfig
+	if err := json.Unmarshal(data, &configs); err != nil {
+		return nil, err
+	}
+	return configs, nil
+}
+
+// BuildPolicies translates a CampaignPolicyConfig into the ordered list of
+// Policy values Compose expects (outermost first: retry, then hedge, then
+// bulkhead, then timeout, with the circuit breaker supplied separately
+// since it needs a *pacer.CircuitBreaker instance).
+func (c CampaignPolicyConfig) BuildPolicies(events *EventListener) []Policy {
+	var policies []Policy
+
+	if c.Retry != nil {
+		policies = append(policies, &RetryPolicy{
+			MaxAttempts: c.Retry.MaxAttempts,
+			BaseDelay:   time.Duration(c.Retry.BaseDelayMs) * time.Millisecond,
+			MaxDelay:    time.Duration(c.Retry.MaxDelayMs) * time.Millisecond,
+			Events:      events,
+		})
+	}
+
+	if c.Hedge != nil {
+		policies = append(policies, &HedgePolicy{
+			Delay:     time.Duration(c.Hedge.DelayMs) * time.Millisecond,
+			MaxHedges: c.Hedge.MaxHedges,
+			Events:    events,
+		})
+	}
+
+	if c.Bulkhead != nil {
+		policies = append(policies, &BulkheadPolicy{
+			MaxConcurrent: c.Bulkhead.MaxConcurrent,
+			Events:        events,
+		})
+	}
+
+	if c.TimeoutMs > 0 {
+		policies = append(policies, &TimeoutPolicy{
+			Timeout: time.Duration(c.TimeoutMs) * time.Millisecond,
+		})
+	}
+
+	return policies
+}
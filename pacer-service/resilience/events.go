@@ -0,0 +1,35 @@
+package resilience
+
+// EventListener receives structured events emitted by policies so operators
+// can wire logs/metrics without each policy hard-coding a logging library.
+// Any method may be left nil; policies check before invoking.
+type EventListener struct {
+	OnRetry            func(attempt int, err error)
+	OnHedgeCancel      func(winningAttempt int)
+	OnBulkheadRejected func(key string)
+	OnCircuitOpen      func(reason string)
+}
+
+func (l *EventListener) retry(attempt int, err error) {
+	if l != nil && l.OnRetry != nil {
+		l.OnRetry(attempt, err)
+	}
+}
+
+func (l *EventListener) hedgeCancel(winningAttempt int) {
+	if l != nil && l.OnHedgeCancel != nil {
+		l.OnHedgeCancel(winningAttempt)
+	}
+}
+
+func (l *EventListener) bulkheadRejected(key string) {
+	if l != nil && l.OnBulkheadRejected != nil {
+		l.OnBulkheadRejected(key)
+	}
+}
+
+func (l *EventListener) circuitOpen(reason string) {
+	if l != nil && l.OnCircuitOpen != nil {
+		l.OnCircuitOpen(reason)
+	}
+}
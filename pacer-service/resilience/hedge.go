@@ -0,0 +1,66 @@
+package resilience
+
+import (
+	"context"
+	"time"
+)
+
+// hedgeResult carries one attempt's outcome back to the coordinating
+// goroutine in HedgePolicy.Apply.
+type hedgeResult struct {
+	attempt int
+	resp    interface{}
+	err     error
+}
+
+// HedgePolicy fires additional parallel attempts after Delay if the first
+// attempt hasn't completed yet, and returns the first attempt that
+// succeeds. An error is returned once every attempt fired so far has
+// failed. Up to MaxHedges extra attempts are fired, spaced Delay apart.
+type HedgePolicy struct {
+	Delay     time.Duration
+	MaxHedges int
+	Events    *EventListener
+}
+
+func (p *HedgePolicy) Apply(next Decide) Decide {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		results := make(chan hedgeResult, p.MaxHedges+1)
+		fire := func(attempt int) {
+			resp, err := next(ctx, req)
+			select {
+			case results <- hedgeResult{attempt: attempt, resp: resp, err: err}:
+			case <-ctx.Done():
+			}
+		}
+
+		go fire(1)
+
+		fired := 1
+		completed := 0
+		timer := time.NewTimer(p.Delay)
+		defer timer.Stop()
+
+		for {
+			select {
+			case res := <-results:
+				completed++
+				if res.err == nil || completed >= fired {
+					p.Events.hedgeCancel(res.attempt)
+					return res.resp, res.err
+				}
+			case <-timer.C:
+				if fired <= p.MaxHedges {
+					fired++
+					go fire(fired)
+					timer.Reset(p.Delay)
+				}
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+	}
+}
@@ -0,0 +1,43 @@
+// Package resilience composes retry, hedge, bulkhead, timeout, and circuit
+// breaker policies around a pacing decision call, in the style of
+// failsafe-style policy pipelines: each policy wraps the next, and the
+// composed chain is invoked through a single Executor.
+package resilience
+
+import "context"
+
+// Decide is the shape of the call every policy wraps: given a request, it
+// returns a response or an error.
+type Decide func(ctx context.Context, req interface{}) (interface{}, error)
+
+// Policy wraps a Decide function with additional behavior (retrying,
+// hedging, bounding concurrency, enforcing a deadline, or a circuit
+// breaker) and returns a new Decide function with that behavior applied.
+type Policy interface {
+	Apply(next Decide) Decide
+}
+
+// Executor runs a request through a composed policy chain.
+type Executor interface {
+	Run(ctx context.Context, req interface{}) (interface{}, error)
+}
+
+type executor struct {
+	decide Decide
+}
+
+func (e *executor) Run(ctx context.Context, req interface{}) (interface{}, error) {
+	return e.decide(ctx, req)
+}
+
+// Compose builds an Executor that applies policies outer-to-inner: the
+// first policy in the list is the outermost wrapper (it sees the request
+// first and the response/error last), mirroring how failsafe composes its
+// policy pipelines.
+func Compose(terminal Decide, policies ...Policy) Executor {
+	decide := terminal
+	for i := len(policies) - 1; i >= 0; i-- {
+		decide = policies[i].Apply(decide)
+	}
+	return &executor{decide: decide}
+}
@@ -0,0 +1,71 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestCompose_AppliesOuterToInner(t *testing.T) {
+	var order []string
+
+	recordingPolicy := func(name string) Policy {
+		return policyFunc(func(next Decide) Decide {
+			return func(ctx context.Context, req interface{}) (interface{}, error) {
+				order = append(order, name)
+				return next(ctx, req)
+			}
+		})
+	}
+
+	terminal := func(ctx context.Context, req interface{}) (interface{}, error) {
+		order = append(order, "terminal")
+		return "ok", nil
+	}
+
+	exec := Compose(terminal, recordingPolicy("outer"), recordingPolicy("inner"))
+
+	resp, err := exec.Run(context.Background(), nil)
+	if err != nil || resp != "ok" {
+		t.Fatalf("unexpected result: %v, %v", resp, err)
+	}
+
+	expected := []string{"outer", "inner", "terminal"}
+	if len(order) != len(expected) {
+		t.Fatalf("expected order %v, got %v", expected, order)
+	}
+	for i := range expected {
+		if order[i] != expected[i] {
+			t.Fatalf("expected order %v, got %v", expected, order)
+		}
+	}
+}
+
+func TestRetryPolicy_RetriesUntilSuccess(t *testing.T) {
+	attempts := 0
+	terminal := func(ctx context.Context, req interface{}) (interface{}, error) {
+		attempts++
+		if attempts < 3 {
+			return nil, errors.New("transient")
+		}
+		return "ok", nil
+	}
+
+	policy := &RetryPolicy{MaxAttempts: 5}
+	exec := Compose(terminal, policy)
+
+	resp, err := exec.Run(context.Background(), nil)
+	if err != nil || resp != "ok" {
+		t.Fatalf("expected eventual success, got resp=%v err=%v", resp, err)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+// policyFunc adapts a plain function to the Policy interface for tests.
+type policyFunc func(next Decide) Decide
+
+func (f policyFunc) Apply(next Decide) Decide {
+	return f(next)
+}
@@ -0,0 +1,72 @@
+package resilience
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy retries a failed Decide call with exponential backoff and
+// jitter, up to MaxAttempts total attempts (including the first).
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	// Retryable decides whether err is worth retrying. Nil means retry on
+	// any non-nil error.
+	Retryable func(err error) bool
+	Events    *EventListener
+}
+
+func (p *RetryPolicy) Apply(next Decide) Decide {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		var lastErr error
+		attempts := p.MaxAttempts
+		if attempts <= 0 {
+			attempts = 1
+		}
+
+		for attempt := 1; attempt <= attempts; attempt++ {
+			resp, err := next(ctx, req)
+			if err == nil {
+				return resp, nil
+			}
+			lastErr = err
+
+			retryable := p.Retryable == nil || p.Retryable(err)
+			if !retryable || attempt == attempts {
+				return resp, err
+			}
+
+			p.Events.retry(attempt, err)
+
+			delay := p.backoff(attempt)
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+
+		return nil, lastErr
+	}
+}
+
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	base := p.BaseDelay
+	if base <= 0 {
+		base = 10 * time.Millisecond
+	}
+	max := p.MaxDelay
+	if max <= 0 {
+		max = time.Second
+	}
+
+	delay := base << uint(attempt-1)
+	if delay > max || delay <= 0 {
+		delay = max
+	}
+
+	jitter := time.Duration(rand.Int63n(int64(delay) + 1))
+	return jitter/2 + delay/2
+}
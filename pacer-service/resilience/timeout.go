@@ -0,0 +1,20 @@
+package resilience
+
+import (
+	"context"
+	"time"
+)
+
+// TimeoutPolicy bounds the time a call is allowed to take, regardless of
+// any deadline already present on ctx.
+type TimeoutPolicy struct {
+	Timeout time.Duration
+}
+
+func (p *TimeoutPolicy) Apply(next Decide) Decide {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		ctx, cancel := context.WithTimeout(ctx, p.Timeout)
+		defer cancel()
+		return next(ctx, req)
+	}
+}